@@ -1,31 +1,30 @@
 package main
 
 import (
-	"context"
 	"errors"
 	"flag"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
+	"strings"
 
 	"github.com/cristalhq/acmd"
 
-	"github.com/neotoolkit/dummy/internal/config"
-	"github.com/neotoolkit/dummy/internal/logger"
-	"github.com/neotoolkit/dummy/internal/parse"
-	"github.com/neotoolkit/dummy/internal/server"
+	"github.com/neotoolkit/dummy/internal/exitcode"
 )
 
 const version = "0.2.1"
 
+// errHelp is returned by parseArgs if usage of command is requested, it is not error of program
+var errHelp = errors.New("help requested")
+
 func main() {
 	err := run()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "server run error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "dummy: %v\n", err)
 	}
+
+	os.Exit(exitcode.Code(err))
 }
 
 func run() error {
@@ -34,53 +33,115 @@ func run() error {
 			Name:        "server",
 			Alias:       "s",
 			Description: "run mock server",
-			Do: func(ctx context.Context, args []string) error {
-				cfg := config.NewConfig()
+			Do:          serverCommand,
+		},
+		{
+			Name:        "record",
+			Alias:       "r",
+			Description: "proxy requests to backend and record them for replay",
+			Do:          recordCommand,
+		},
+		{
+			Name:        "validate",
+			Alias:       "v",
+			Description: "report problems of specification",
+			Do:          validateCommand,
+		},
+	}
 
-				cfg.Server.Path = args[0]
+	r := acmd.RunnerOf(cmds, acmd.Config{
+		AppName:         "dummy",
+		Version:         version,
+		PostDescription: `Run "dummy <command> -h" to see usage of command.`,
+	})
+
+	err := r.Run()
+	if errors.Is(err, acmd.ErrNoArgs) {
+		return exitcode.Wrap(exitcode.Usage, errors.New(`command is required, run "dummy help" to see commands`))
+	}
 
-				fs := flag.NewFlagSet("dummy", flag.ContinueOnError)
-				fs.StringVar(&cfg.Server.Port, "port", "8080", "")
-				fs.StringVar(&cfg.Logger.Level, "logger-level", "INFO", "")
-				if err := fs.Parse(args[1:]); err != nil {
-					return err
-				}
+	var e *exitcode.Error
+	if err != nil && !errors.As(err, &e) {
+		// commands return errors with exit code, other errors are errors of command line, e.g. unknown command
+		return exitcode.Wrap(exitcode.Usage, err)
+	}
 
-				api, err := parse.Parse(cfg.Server.Path)
-				if err != nil {
-					return fmt.Errorf("specification parse error: %w", err)
-				}
+	return err
+}
 
-				l := logger.NewLogger(cfg.Logger.Level)
-				h := server.NewHandlers(api, l)
-				s := server.NewServer(cfg.Server, l, h)
+// newFlagSet returns flag set of command which prints usage with every flag, synopsis is usage without program name
+func newFlagSet(command, synopsis, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
 
-				go func() {
-					if err := s.Run(); !errors.Is(err, http.ErrServerClosed) {
-						l.Logger.Err(err).Msg("run server")
-					}
-				}()
+	// errors are printed by main, so flag set only prints usage
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {
+		w := os.Stdout
 
-				interrupt := make(chan os.Signal, 1)
-				signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		fmt.Fprintf(w, "%s\n\nUsage:\n\n    dummy %s\n\nFlags:\n\n", description, synopsis)
 
-				x := <-interrupt
-				l.Info().Msgf("received `%v`", x)
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+		fs.SetOutput(io.Discard)
 
-				const timeout = 5 * time.Second
+		fmt.Fprintln(w)
+	}
 
-				ctx, cancelFunc := context.WithTimeout(ctx, timeout)
-				defer cancelFunc()
+	return fs
+}
 
-				return s.Stop(ctx)
-			},
-		},
+// parseArgs parses flags which may be placed before, after or between arguments and returns arguments
+// Arguments after "--" are not parsed as flags
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	// flag set prints usage on every error, but usage is printed only if it is requested
+	usage := fs.Usage
+	fs.Usage = func() {}
+
+	defer func() {
+		fs.Usage = usage
+	}()
+
+	for len(args) > 0 {
+		err := fs.Parse(args)
+		if errors.Is(err, flag.ErrHelp) {
+			usage()
+
+			return nil, errHelp
+		}
+
+		if err != nil {
+			return nil, usageError(fs.Name(), "%v", err)
+		}
+
+		rest := fs.Args()
+
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+
+			break
+		}
+
+		if len(rest) == 0 {
+			break
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
 	}
 
-	r := acmd.RunnerOf(cmds, acmd.Config{
-		AppName: "Dummy",
-		Version: version,
-	})
+	return positional, nil
+}
+
+// usageError returns error of command line arguments with hint to see usage of command
+func usageError(command, format string, a ...interface{}) error {
+	msg := fmt.Sprintf(format, a...)
+
+	return exitcode.Wrap(exitcode.Usage, fmt.Errorf("%s, run \"dummy %s -h\" to see usage", msg, command))
+}
 
-	return r.Run()
+// unexpectedArgs returns error for arguments which are not expected by command
+func unexpectedArgs(command string, args []string) error {
+	return usageError(command, "unexpected arguments: %s", strings.Join(args, " "))
 }
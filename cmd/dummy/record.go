@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/neotoolkit/dummy/internal/exitcode"
+	"github.com/neotoolkit/dummy/internal/logger"
+	"github.com/neotoolkit/dummy/internal/middleware"
+	"github.com/neotoolkit/dummy/internal/parse"
+	"github.com/neotoolkit/dummy/internal/record"
+)
+
+func recordCommand(_ context.Context, args []string) error {
+	fs := newFlagSet("record", "record -target <url> [flags] <specification>", "Proxy requests to target and save requests with responses, responses which differ from specification are reported.\nRecordings are replayed by \"dummy server -replay <dir>\".")
+	target := fs.String("target", "", "URL of backend which receives requests")
+	out := fs.String("out", "recordings", "directory of recordings")
+	host := fs.String("host", "", "listen address, all interfaces by default")
+	port := fs.String("port", "8080", "listen port")
+	level := fs.String("logger-level", "INFO", "logger level: DEBUG, INFO, WARN or ERROR")
+
+	positional, err := parseArgs(fs, args)
+	if errors.Is(err, errHelp) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		return usageError(fs.Name(), "%v", errEmptyPath)
+	}
+
+	if len(positional) > 1 {
+		return unexpectedArgs(fs.Name(), positional[1:])
+	}
+
+	if *target == "" {
+		return usageError(fs.Name(), "target is required")
+	}
+
+	u, err := url.Parse(*target)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return usageError(fs.Name(), "target must be absolute URL, got %s", *target)
+	}
+
+	if n, err := strconv.Atoi(*port); err != nil || n < 1 || n > 65535 {
+		return usageError(fs.Name(), "port must be number between 1 and 65535, got %s", *port)
+	}
+
+	spec, err := parse.Parse(positional[0])
+	if err != nil {
+		return exitcode.Wrap(exitcode.Spec, fmt.Errorf("specification parse error: %w", err))
+	}
+
+	l := logger.NewLogger(*level)
+
+	s := &http.Server{
+		Addr:    *host + ":" + *port,
+		Handler: middleware.Logging(record.NewRecorder(u, *out, spec, l), l),
+	}
+
+	l.Info().Msgf("Recording requests to %s on %s port", u, *port)
+
+	return serve(l, s.ListenAndServe, s.Shutdown)
+}
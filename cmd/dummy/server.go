@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/config"
+	"github.com/neotoolkit/dummy/internal/exitcode"
+	"github.com/neotoolkit/dummy/internal/journal"
+	"github.com/neotoolkit/dummy/internal/logger"
+	"github.com/neotoolkit/dummy/internal/parse"
+	"github.com/neotoolkit/dummy/internal/proxy"
+	"github.com/neotoolkit/dummy/internal/record"
+	"github.com/neotoolkit/dummy/internal/server"
+	"github.com/neotoolkit/dummy/internal/store"
+	"github.com/neotoolkit/dummy/internal/watch"
+)
+
+var (
+	errEmptyPath    = errors.New("specification path is required")
+	errGraphQLMount = errors.New("GraphQL specification must be the only specification of port")
+)
+
+// flagKeys maps flags of server command to configuration keys, flags take precedence over configuration file
+var flagKeys = map[string]string{
+	"host":           "server.host",
+	"port":           "server.port",
+	"logger-level":   "logger.level",
+	"stateful":       "server.stateful",
+	"seed":           "server.seed",
+	"watch":          "server.watch",
+	"watch-interval": "server.watch-interval",
+	"latency":        "server.latency",
+	"example-policy": "server.example-policy",
+	"journal-limit":  "server.journal-limit",
+	"replay":         "server.replay",
+	"base-path":      "server.base-path",
+	"upstream":       "server.upstream",
+	"cors":           "server.cors.enabled",
+	"tls-cert":       "server.tls.cert",
+	"tls-key":        "server.tls.key",
+}
+
+func serverCommand(ctx context.Context, args []string) error {
+	fs := serverFlags()
+
+	positional, err := parseArgs(fs, args)
+	if errors.Is(err, errHelp) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(fs)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Usage, err)
+	}
+
+	// specifications may be omitted if they are set in configuration file
+	if len(positional) > 0 {
+		cfg.Server.Path = positional[0]
+		cfg.Server.Specs = positional[1:]
+
+		if err := cfg.Validate(); err != nil {
+			return exitcode.Wrap(exitcode.Usage, err)
+		}
+	}
+
+	if len(cfg.Server.Mounts()) == 0 {
+		return usageError(fs.Name(), "%v", errEmptyPath)
+	}
+
+	l := logger.NewLogger(cfg.Logger.Level)
+
+	policy, err := api.ParseExamplePolicy(cfg.Server.ExamplePolicy)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Usage, err)
+	}
+
+	parseOption := parse.WithExamplePolicy(policy, func(err error) {
+		msg := "example does not match schema"
+		if policy == api.ExamplePolicyFallback {
+			msg += ", value of schema is served"
+		}
+
+		l.Warn().Err(err).Msg(msg)
+	})
+
+	ctx, cancelWatch := context.WithCancel(ctx)
+	defer cancelWatch()
+
+	var servers []*server.Server
+
+	for _, g := range groupMounts(cfg.Server.Port, cfg.Server.Mounts()) {
+		mounts := g.mounts
+
+		load := func() (api.API, error) {
+			return parseMounts(mounts, cfg.Server.BasePath, parseOption)
+		}
+
+		spec, err := load()
+		if err != nil {
+			return exitcode.Wrap(exitcode.Spec, fmt.Errorf("specification parse error: %w", err))
+		}
+
+		h, err := newHandlers(cfg.Server, spec, l)
+		if err != nil {
+			return err
+		}
+
+		conf := cfg.Server
+		conf.Port = g.port
+
+		servers = append(servers, server.NewServer(conf, l, h))
+
+		if !cfg.Server.Watch {
+			continue
+		}
+
+		// specifications of port are reloaded together, so change of one of them reloads the joined API
+		for _, m := range mounts {
+			w := watch.NewWatcher(m.Path, cfg.Server.WatchInterval)
+
+			go w.Run(ctx, func() {
+				spec, err := load()
+				if err != nil {
+					l.Error().Err(err).Msg("reload specification, previous version is served")
+
+					return
+				}
+
+				// objects of store are described by the previous specification, so they are dropped with it
+				if cfg.Server.Stateful {
+					h.SetStore(store.NewStore(spec))
+				}
+
+				h.SetAPI(spec)
+				l.Info().Msg("specification reloaded")
+			})
+		}
+	}
+
+	return serve(l, func() error {
+		errs := make(chan error, len(servers))
+
+		for _, s := range servers {
+			go func(s *server.Server) {
+				errs <- s.Run()
+			}(s)
+		}
+
+		return <-errs
+	}, func(ctx context.Context) error {
+		var err error
+
+		for _, s := range servers {
+			if e := s.Stop(ctx); e != nil && err == nil {
+				err = e
+			}
+		}
+
+		return err
+	})
+}
+
+// newHandlers returns handlers of specification configured by server configuration
+func newHandlers(cfg config.Server, spec api.API, l *logger.Logger) (server.Handlers, error) {
+	h := server.NewHandlers(spec, l)
+
+	if cfg.Seed != 0 {
+		h.Seeder = server.NewSeeder(cfg.Seed)
+	}
+
+	if cfg.JournalLimit > 0 {
+		h.Journal = journal.NewJournal(cfg.JournalLimit)
+	} else {
+		h.Journal = nil
+	}
+
+	if cfg.Upstream != "" {
+		// upstream is validated with configuration
+		u, _ := url.Parse(cfg.Upstream)
+		h.Upstream = proxy.New(u, l)
+	}
+
+	if cfg.Replay != "" {
+		recordings, err := record.Load(cfg.Replay)
+		if err != nil {
+			return server.Handlers{}, exitcode.Wrap(exitcode.Usage, fmt.Errorf("load recordings: %w", err))
+		}
+
+		for _, rec := range recordings {
+			if _, err := h.Stubs.Add(rec.Stub()); err != nil {
+				return server.Handlers{}, exitcode.Wrap(exitcode.Usage, fmt.Errorf("recording %s: %w", rec.Name, err))
+			}
+		}
+
+		l.Info().Msgf("replay %d recordings", len(recordings))
+	}
+
+	if cfg.Stateful {
+		h.SetStore(store.NewStore(spec))
+	}
+
+	return h, nil
+}
+
+// portMounts are specifications which are served by one port
+type portMounts struct {
+	port   string
+	mounts []config.Mount
+}
+
+// groupMounts returns specifications grouped by port in order of the first specification of port
+// Specification without port is served by port of server
+func groupMounts(port string, mounts []config.Mount) []portMounts {
+	var groups []portMounts
+
+	index := make(map[string]int)
+
+	for _, m := range mounts {
+		p := m.Port
+		if p == "" {
+			p = port
+		}
+
+		i, ok := index[p]
+		if !ok {
+			i = len(groups)
+			index[p] = i
+			groups = append(groups, portMounts{port: p})
+		}
+
+		groups[i].mounts = append(groups[i].mounts, m)
+	}
+
+	return groups
+}
+
+// parseMounts returns API of specifications which are served by one port, operations of every specification are
+// served under its prefix, base path or base path of its servers, the first specification wins if operations repeat
+func parseMounts(mounts []config.Mount, basePath string, opt parse.Option) (api.API, error) {
+	var operations []api.Operation
+
+	for _, m := range mounts {
+		opts := []parse.Option{opt}
+
+		switch {
+		case m.Prefix != "":
+			opts = append(opts, parse.WithBasePath(m.Prefix))
+		case basePath != "":
+			opts = append(opts, parse.WithBasePath(basePath))
+		}
+
+		spec, err := parse.Parse(m.Path, opts...)
+		if err != nil {
+			if len(mounts) > 1 {
+				return api.API{}, fmt.Errorf("%s: %w", m.Path, err)
+			}
+
+			return api.API{}, err
+		}
+
+		if len(mounts) == 1 {
+			return spec, nil
+		}
+
+		if spec.GraphQL != nil {
+			return api.API{}, fmt.Errorf("%s: %w", m.Path, errGraphQLMount)
+		}
+
+		operations = append(operations, spec.Operations...)
+	}
+
+	return api.NewAPI(operations), nil
+}
+
+// serve runs server until interrupt signal and stops it
+func serve(l *logger.Logger, run func() error, stop func(ctx context.Context) error) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- run()
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-errs:
+		return exitcode.Wrap(exitcode.Failure, fmt.Errorf("run server: %w", err))
+	case x := <-interrupt:
+		l.Info().Msgf("received `%v`", x)
+	}
+
+	const timeout = 5 * time.Second
+
+	// ctx may be already canceled by signal, so server is stopped with own timeout
+	stopCtx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+	defer cancelFunc()
+
+	if err := stop(stopCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return exitcode.Wrap(exitcode.Failure, fmt.Errorf("stop server: %w", err))
+	}
+
+	return nil
+}
+
+func serverFlags() *flag.FlagSet {
+	fs := newFlagSet("server", "server [flags] [specification...]", "Run mock server from OpenAPI, Swagger or GraphQL specification files or URLs.\n"+
+		"Specification is mounted at prefix or port by [:port][/prefix]=specification, e.g. :8081/orders=orders.yml")
+	fs.String("config", "", "path to configuration file, "+config.DefaultPath+" is used if it exists")
+	fs.String("host", "", "listen address, all interfaces by default")
+	fs.String("port", "8080", "listen port")
+	fs.String("logger-level", "INFO", "logger level: DEBUG, INFO, WARN or ERROR")
+	fs.Bool("stateful", false, "keep created, updated and deleted objects in memory")
+	fs.Int64("seed", 0, "seed of generated values, random by default")
+	fs.Bool("watch", false, "reload specification on change")
+	fs.Duration("watch-interval", 0, "polling interval of specification, 1s for files and 30s for URLs by default")
+	fs.Duration("latency", 0, "delay of every response")
+	fs.String("upstream", "", "URL of backend which serves operations which are not specified or are marked by x-dummy-proxy")
+	fs.String("base-path", "", "base path of operations instead of base path of servers of specification, / serves them at root")
+	fs.String("replay", "", "directory of recordings which are served before responses of specification")
+	fs.Int("journal-limit", 1000, "number of requests which are kept for /__dummy/requests, 0 disables journal")
+	fs.String("example-policy", "warn", "policy for response examples which do not match their schemas: warn, fail or fallback")
+	fs.Bool("cors", false, "allow cross-origin requests from any origin")
+	fs.String("tls-cert", "", "path to TLS certificate")
+	fs.String("tls-key", "", "path to TLS key")
+
+	return fs
+}
+
+// loadConfig returns configuration from file, DUMMY_* environment variables and flags in order of precedence
+func loadConfig(fs *flag.FlagSet) (*config.Config, error) {
+	cfg := config.NewConfig()
+
+	path := fs.Lookup("config").Value.String()
+	if path == "" {
+		if _, err := os.Stat(config.DefaultPath); err == nil {
+			path = config.DefaultPath
+		}
+	}
+
+	if path != "" {
+		if err := cfg.Load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.LoadEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	fs.Visit(func(f *flag.Flag) {
+		key, ok := flagKeys[f.Name]
+		if ok && err == nil {
+			err = cfg.Set(key, f.Value.String())
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
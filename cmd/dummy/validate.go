@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/exitcode"
+	"github.com/neotoolkit/dummy/internal/parse"
+)
+
+// validateReport is output of validate command in JSON format
+type validateReport struct {
+	Valid    bool          `json:"valid"`
+	Problems []api.Problem `json:"problems"`
+}
+
+func validateCommand(_ context.Context, args []string) error {
+	fs := newFlagSet("validate", "validate [flags] <specification>", "Report every problem of specification, exit code is 3 if specification is invalid.")
+	format := fs.String("format", "text", "output format: text or json")
+
+	positional, err := parseArgs(fs, args)
+	if errors.Is(err, errHelp) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *format != "text" && *format != "json" {
+		return usageError(fs.Name(), "format must be text or json, got %s", *format)
+	}
+
+	if len(positional) == 0 {
+		return usageError(fs.Name(), "%v", errEmptyPath)
+	}
+
+	if len(positional) > 1 {
+		return unexpectedArgs(fs.Name(), positional[1:])
+	}
+
+	problems, err := parse.Validate(positional[0])
+	if err != nil {
+		return exitcode.Wrap(exitcode.Spec, fmt.Errorf("specification parse error: %w", err))
+	}
+
+	if err := printProblems(os.Stdout, *format, problems); err != nil {
+		return exitcode.Wrap(exitcode.Failure, err)
+	}
+
+	if len(problems) > 0 {
+		return exitcode.Wrap(exitcode.Spec, fmt.Errorf("specification has %d problems", len(problems)))
+	}
+
+	return nil
+}
+
+func printProblems(w io.Writer, format string, problems []api.Problem) error {
+	if format == "json" {
+		if nil == problems {
+			problems = []api.Problem{}
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(validateReport{
+			Valid:    len(problems) == 0,
+			Problems: problems,
+		})
+	}
+
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(w, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
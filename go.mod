@@ -5,9 +5,9 @@ go 1.17
 require (
 	github.com/cristalhq/acmd v0.5.6
 	github.com/goccy/go-yaml v1.9.5
+	github.com/graphql-go/graphql v0.8.1
 	github.com/lamoda/gonkey v1.13.2
 	github.com/neotoolkit/faker v0.1.2
-	github.com/neotoolkit/openapi v0.3.1
 	github.com/rs/zerolog v1.26.1
 	github.com/stretchr/testify v1.7.0
 )
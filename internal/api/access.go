@@ -0,0 +1,154 @@
+package api
+
+import (
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+// direction is side of exchange which schema is converted for
+// readOnly properties are not required in requests and writeOnly properties are omitted in responses
+type direction int
+
+const (
+	directionAny direction = iota
+	directionRequest
+	directionResponse
+)
+
+// in returns copy of builder which converts schemas for direction
+func (b *Builder) in(d direction) *Builder {
+	res := *b
+	res.direction = d
+
+	return &res
+}
+
+// access returns readOnly and writeOnly of property, they are looked up in referenced schema too
+func (b *Builder) access(prop openapi.Schema) (readOnly, writeOnly bool) {
+	readOnly, writeOnly = prop.ReadOnly, prop.WriteOnly
+
+	if prop.Ref != "" {
+		if s, err := b.OpenAPI.LookupByReference(prop.Ref); err == nil {
+			readOnly = readOnly || s.ReadOnly
+			writeOnly = writeOnly || s.WriteOnly
+		}
+	}
+
+	return readOnly, writeOnly
+}
+
+// omits returns true if property is omitted in direction of builder, it is writeOnly property of response
+// readOnly property of request is not omitted, client may send it back
+func (b *Builder) omits(prop openapi.Schema) bool {
+	_, writeOnly := b.access(prop)
+
+	return b.direction == directionResponse && writeOnly
+}
+
+// required returns required properties of object without readOnly properties of request
+// and writeOnly properties of response
+func (b *Builder) required(s openapi.Schema) []string {
+	if b.direction == directionAny {
+		return s.Required
+	}
+
+	var required []string
+
+	for _, name := range s.Required {
+		if prop := s.Properties[name]; prop != nil {
+			readOnly, writeOnly := b.access(*prop)
+
+			if (b.direction == directionRequest && readOnly) || (b.direction == directionResponse && writeOnly) {
+				continue
+			}
+		}
+
+		required = append(required, name)
+	}
+
+	return required
+}
+
+// stripWriteOnly returns copy of response example without writeOnly properties of schema
+func (b *Builder) stripWriteOnly(s openapi.Schema, value interface{}) interface{} {
+	if b.direction != directionResponse {
+		return value
+	}
+
+	if s.Ref != "" {
+		schema, err := b.OpenAPI.LookupByReference(s.Ref)
+		if err != nil {
+			return value
+		}
+
+		s = schema
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if nil == s.Items {
+			return v
+		}
+
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = b.stripWriteOnly(*s.Items, item)
+		}
+
+		return res
+	case map[string]interface{}:
+		props := b.properties(s)
+		res := make(map[string]interface{}, len(v))
+
+		for key, item := range v {
+			prop, ok := props[key]
+			if !ok {
+				res[key] = item
+
+				continue
+			}
+
+			if b.omits(*prop) {
+				continue
+			}
+
+			res[key] = b.stripWriteOnly(*prop, item)
+		}
+
+		return res
+	}
+
+	return value
+}
+
+// properties returns properties of object with properties of its allOf members
+func (b *Builder) properties(s openapi.Schema) openapi.Schemas {
+	props := make(openapi.Schemas, len(s.Properties))
+
+	for _, member := range s.AllOf {
+		if nil == member {
+			continue
+		}
+
+		m := *member
+		if m.Ref != "" {
+			schema, err := b.OpenAPI.LookupByReference(m.Ref)
+			if err != nil {
+				continue
+			}
+
+			m = schema
+		}
+
+		for name, prop := range b.properties(m) {
+			props[name] = prop
+		}
+	}
+
+	for name, prop := range s.Properties {
+		if prop != nil {
+			props[name] = prop
+		}
+	}
+
+	return props
+}
@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/neotoolkit/faker"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func accessBuilder() api.Builder {
+	return api.Builder{
+		OpenAPI: openapi.OpenAPI{
+			Components: openapi.Components{
+				Schemas: openapi.Schemas{
+					"ID": {Type: "string", Format: "uuid", ReadOnly: true},
+					"User": {
+						Type: "object",
+						Properties: openapi.Schemas{
+							"id":       {Ref: "#/components/schemas/ID"},
+							"name":     {Type: "string"},
+							"password": {Type: "string", WriteOnly: true},
+						},
+						Required: []string{"id", "name", "password"},
+					},
+				},
+			},
+		},
+		Faker: faker.NewFaker(),
+	}
+}
+
+func TestBuilder_ReadOnly(t *testing.T) {
+	b := accessBuilder()
+
+	operation, err := b.Set("/users", "POST", &openapi.Operation{
+		RequestBody: openapi.RequestBody{
+			Required: true,
+			Content: openapi.Content{
+				"application/json": {Schema: openapi.Schema{Ref: "#/components/schemas/User"}},
+			},
+		},
+		Responses: openapi.Responses{
+			"201": {},
+		},
+	})
+	require.NoError(t, err)
+
+	schema := operation.RequestBody.Content[0].Schema
+
+	require.Empty(t, schema.Validate("", map[string]interface{}{"name": "Elon", "password": "secret"}))
+	require.Empty(t, schema.Validate("", map[string]interface{}{"id": "e1afccea-5168-4735-84d4-cb96f6fb5d25", "name": "Elon", "password": "secret"}))
+	require.Equal(t, api.ValidationErrors{
+		{Pointer: "/name", Message: "required property is missing"},
+		{Pointer: "/password", Message: "required property is missing"},
+	}, schema.Validate("", map[string]interface{}{}))
+
+	a := api.NewAPI([]api.Operation{operation})
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	got, err := a.FindResponse(api.FindResponseParams{
+		Path:   "/users",
+		Method: "POST",
+		Body:   io.NopCloser(strings.NewReader(`{"name": "Elon", "password": "secret"}`)),
+		Header: header,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 201, got.StatusCode)
+}
+
+func TestBuilder_WriteOnly(t *testing.T) {
+	b := accessBuilder()
+
+	operation, err := b.Set("/users/{userId}", "GET", &openapi.Operation{
+		Responses: openapi.Responses{
+			"200": {
+				Content: openapi.Content{
+					"application/json": {
+						Schema: openapi.Schema{Ref: "#/components/schemas/User"},
+						Examples: openapi.Examples{
+							"elon": {Value: map[string]interface{}{"id": "e1afccea-5168-4735-84d4-cb96f6fb5d25", "name": "Elon", "password": "secret"}},
+						},
+					},
+				},
+			},
+			"201": {
+				Content: openapi.Content{
+					"application/json": {Schema: openapi.Schema{Ref: "#/components/schemas/User"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	example := operation.Responses[0]
+
+	require.Equal(t, map[string]interface{}{"id": "e1afccea-5168-4735-84d4-cb96f6fb5d25", "name": "Elon"}, example.ExampleValue("elon"))
+	require.Equal(t, map[string]interface{}{"id": "e1afccea-5168-4735-84d4-cb96f6fb5d25", "name": "Elon"}, example.ExampleValue(""))
+	require.Empty(t, example.Schema.Validate("", map[string]interface{}{"id": "e1afccea-5168-4735-84d4-cb96f6fb5d25", "name": "Elon"}))
+
+	generated, ok := operation.Responses[1].DynamicValue("", b.Faker).(map[string]interface{})
+
+	require.True(t, ok)
+	require.Contains(t, generated, "id")
+	require.Contains(t, generated, "name")
+	require.NotContains(t, generated, "password")
+}
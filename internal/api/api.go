@@ -1,22 +1,73 @@
 package api
 
+import (
+	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/graphql"
+)
+
 // API -.
 type API struct {
 	Operations []Operation
+	GraphQL    *graphql.Schema
+	// router is built from operations by NewAPI, API without router builds it for every lookup
+	router *Router
+}
+
+// NewAPI returns API with router of operations
+func NewAPI(operations []Operation) API {
+	return API{
+		Operations: operations,
+		router:     NewRouter(operations),
+	}
+}
+
+// Router returns router of operations
+func (a API) Router() *Router {
+	if nil == a.router {
+		return NewRouter(a.Operations)
+	}
+
+	return a.router
 }
 
 // Operation -.
 type Operation struct {
-	Method    string
-	Path      string
-	Body      map[string]FieldType
-	Responses []Response
+	Method string
+	Path   string
+	// RequestBody is request body of every media type, nil if operation has no request body
+	RequestBody *RequestBody
+	Parameters  []Parameter
+	Responses   []Response
+	// Proxy is true if operation is proxied to upstream instead of mocking
+	Proxy bool
+}
+
+// Parameter is path, query, header or cookie parameter of operation
+type Parameter struct {
+	Name     string
+	In       string
+	Required bool
+	// Style defines how parameter value is serialized, e.g. simple, form or deepObject
+	Style   string
+	Explode bool
+	Schema  Schema
 }
 
-// FieldType -.
-type FieldType struct {
+// RequestBody -.
+type RequestBody struct {
 	Required bool
-	Type     string
+	// Content is request body of every media type ordered by media type
+	Content []RequestContent
+}
+
+// RequestContent is request body of media type
+type RequestContent struct {
+	// MediaType may be range, e.g. image/*
+	MediaType string
+	Schema    Schema
+	// Encoding is content type of multipart part by property name
+	Encoding map[string]string
 }
 
 // Response -.
@@ -26,6 +77,8 @@ type Response struct {
 	Schema     Schema
 	Example    interface{}
 	Examples   map[string]interface{}
+	// Headers are response headers ordered by name
+	Headers []Header
 }
 
 // ExampleValue -.
@@ -46,14 +99,48 @@ func (r Response) ExampleValue(key string) interface{} {
 	return r.Schema.ExampleValue()
 }
 
+// DynamicValue returns example like ExampleValue, but values without example in specification are generated by faker
+func (r Response) DynamicValue(key string, f faker.Faker) interface{} {
+	if nil == r.Schema {
+		return nil
+	}
+
+	example, ok := r.Examples[key]
+	if ok {
+		return example
+	}
+
+	if r.Example != nil {
+		return r.Example
+	}
+
+	return r.Schema.DynamicValue(f)
+}
+
+// GeneratedValue returns value generated by faker, examples of specification are ignored
+func (r Response) GeneratedValue(f faker.Faker) interface{} {
+	if nil == r.Schema {
+		return nil
+	}
+
+	return Generate(r.Schema, f)
+}
+
 // Schema -.
 type Schema interface {
 	ExampleValue() interface{}
+	// DynamicValue returns example of schema, values without example in specification are generated by faker
+	DynamicValue(f faker.Faker) interface{}
+	// Validate returns violations of value against schema, pointer is JSON pointer to value
+	Validate(pointer string, value interface{}) ValidationErrors
 }
 
 // BooleanSchema -.
 type BooleanSchema struct {
 	Example bool
+	// Dynamic is true if example is not specified and value is generated for every response
+	Dynamic bool
+	XML     *XML
 }
 
 // ExampleValue -.
@@ -63,7 +150,15 @@ func (b BooleanSchema) ExampleValue() interface{} {
 
 // IntSchema -.
 type IntSchema struct {
-	Example int64
+	Example          int64
+	Enum             []interface{}
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MultipleOf       *float64
+	Dynamic          bool
+	XML              *XML
 }
 
 // ExampleValue -.
@@ -73,7 +168,15 @@ func (i IntSchema) ExampleValue() interface{} {
 
 // FloatSchema -.
 type FloatSchema struct {
-	Example float64
+	Example          float64
+	Enum             []interface{}
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MultipleOf       *float64
+	Dynamic          bool
+	XML              *XML
 }
 
 // ExampleValue -.
@@ -83,7 +186,14 @@ func (f FloatSchema) ExampleValue() interface{} {
 
 // StringSchema -.
 type StringSchema struct {
-	Example string
+	Example   string
+	Format    string
+	Enum      []interface{}
+	MinLength *uint64
+	MaxLength *uint64
+	Pattern   string
+	Dynamic   bool
+	XML       *XML
 }
 
 // ExampleValue -.
@@ -93,8 +203,13 @@ func (s StringSchema) ExampleValue() interface{} {
 
 // ArraySchema -.
 type ArraySchema struct {
-	Type    Schema
-	Example []interface{}
+	Type        Schema
+	Example     []interface{}
+	MinItems    *uint64
+	MaxItems    *uint64
+	UniqueItems bool
+	Dynamic     bool
+	XML         *XML
 }
 
 // ExampleValue -.
@@ -110,6 +225,12 @@ func (a ArraySchema) ExampleValue() interface{} {
 type ObjectSchema struct {
 	Properties map[string]Schema
 	Example    map[string]interface{}
+	Required   []string
+	// AdditionalProperties is schema of properties which are not listed in Properties, nil allows any
+	AdditionalProperties Schema
+	MinProperties        *uint64
+	MaxProperties        *uint64
+	XML                  *XML
 }
 
 // ExampleValue -.
@@ -130,9 +251,37 @@ func (o ObjectSchema) ExampleValue() interface{} {
 // FakerSchema -.
 type FakerSchema struct {
 	Example interface{}
+	// Name is name of faker function, e.g. person.name
+	Name string
 }
 
 // ExampleValue -.
 func (f FakerSchema) ExampleValue() interface{} {
 	return f.Example
 }
+
+// AnySchema is empty schema which allows any value
+type AnySchema struct{}
+
+// ExampleValue -.
+func (AnySchema) ExampleValue() interface{} {
+	return nil
+}
+
+// FalseSchema is schema which allows no value, e.g. additionalProperties: false
+type FalseSchema struct{}
+
+// ExampleValue -.
+func (FalseSchema) ExampleValue() interface{} {
+	return nil
+}
+
+// NullableSchema -.
+type NullableSchema struct {
+	Schema Schema
+}
+
+// ExampleValue -.
+func (n NullableSchema) ExampleValue() interface{} {
+	return n.Schema.ExampleValue()
+}
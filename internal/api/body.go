@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+// Media types of request bodies which are decoded to objects, JSON is detected by IsJSONMediaType
+const (
+	FormURLEncoded = "application/x-www-form-urlencoded"
+	MultipartForm  = "multipart/form-data"
+)
+
+// UnsupportedMediaTypeError is returned if media type of request body is not declared by operation
+type UnsupportedMediaTypeError struct {
+	ContentType string
+	MediaTypes  []string
+}
+
+// Error -.
+func (e *UnsupportedMediaTypeError) Error() string {
+	return "content type must be one of " + strings.Join(e.MediaTypes, ", ") + ", got " + e.ContentType
+}
+
+// validateBody decodes request body according to Content-Type header and validates it against schema of media type
+// Empty body is valid if request body is not required
+func (o Operation) validateBody(params FindResponseParams) (ValidationErrors, error) {
+	var data []byte
+
+	if params.Body != nil {
+		b, err := io.ReadAll(params.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		data = b
+	}
+
+	if len(data) == 0 {
+		if o.RequestBody.Required {
+			return violation("", "request body is required"), nil
+		}
+
+		return nil, nil
+	}
+
+	content, body, errs, err := o.decodeBody(params.Header.Get("Content-Type"), data)
+	if err != nil || len(errs) > 0 {
+		return errs, err
+	}
+
+	if nil == content.Schema {
+		return nil, nil
+	}
+
+	return content.Schema.Validate("", body), nil
+}
+
+// DecodeBody returns request body decoded according to content type, JSON and forms are objects, other bodies are strings
+// Body of operation without request body is decoded as JSON
+func (o Operation) DecodeBody(contentType string, data []byte) (interface{}, error) {
+	if nil == o.RequestBody {
+		var body interface{}
+
+		err := json.Unmarshal(data, &body)
+
+		return body, err
+	}
+
+	_, body, errs, err := o.decodeBody(contentType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return body, nil
+}
+
+func (o Operation) decodeBody(contentType string, data []byte) (RequestContent, interface{}, ValidationErrors, error) {
+	content, ok := o.RequestBody.find(contentType)
+	if !ok {
+		mediaTypes := make([]string, len(o.RequestBody.Content))
+		for i, c := range o.RequestBody.Content {
+			mediaTypes[i] = c.MediaType
+		}
+
+		return RequestContent{}, nil, nil, &UnsupportedMediaTypeError{ContentType: contentType, MediaTypes: mediaTypes}
+	}
+
+	body, errs, err := content.decode(contentType, data)
+
+	return content, body, errs, err
+}
+
+// find returns content with the most specific media type which matches content type of request
+// JSON or the first media type is used if request has no content type
+func (b *RequestBody) find(contentType string) (RequestContent, bool) {
+	if strings.TrimSpace(contentType) == "" {
+		for _, c := range b.Content {
+			if IsJSONMediaType(c.MediaType) {
+				return c, true
+			}
+		}
+
+		return b.Content[0], true
+	}
+
+	var (
+		found       RequestContent
+		specificity int
+	)
+
+	for _, c := range b.Content {
+		for _, r := range parseAccept(c.MediaType) {
+			if s := r.specificity(contentType); s > specificity {
+				found, specificity = c, s
+			}
+		}
+	}
+
+	return found, specificity > 0
+}
+
+// decode returns body in form which is validated by schema: JSON and form values are objects, other bodies are strings
+func (c RequestContent) decode(contentType string, data []byte) (interface{}, ValidationErrors, error) {
+	typ, subtype := splitMediaType(contentType)
+	if typ == "" {
+		typ, subtype = splitMediaType(c.MediaType)
+	}
+
+	mediaType := typ + "/" + subtype
+
+	switch {
+	case IsJSONMediaType(mediaType):
+		var body interface{}
+
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, nil, err
+		}
+
+		return body, nil, nil
+	case mediaType == FormURLEncoded:
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, violation("", "form is malformed: %v", err), nil
+		}
+
+		return Coerce(c.Schema, formValues(c.Schema, values)), nil, nil
+	case mediaType == MultipartForm:
+		return c.decodeMultipart(contentType, data)
+	default:
+		return string(data), nil, nil
+	}
+}
+
+// decodeMultipart returns object of parts, file part is string with content of file and part with JSON is decoded
+// Content type of part must match encoding of property
+func (c RequestContent) decodeMultipart(contentType string, data []byte) (interface{}, ValidationErrors, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["boundary"] == "" {
+		return nil, violation("", "multipart boundary is missing"), nil
+	}
+
+	values := make(map[string][]string)
+	partTypes := make(map[string]string)
+
+	var errs ValidationErrors
+
+	r := multipart.NewReader(bytes.NewReader(data), params["boundary"])
+
+	for {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, violation("", "multipart is malformed: %v", err), nil
+		}
+
+		name := part.FormName()
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, violation("", "multipart is malformed: %v", err), nil
+		}
+
+		partType := part.Header.Get("Content-Type")
+		if partType == "" {
+			partType = "text/plain"
+			if part.FileName() != "" {
+				partType = "application/octet-stream"
+			}
+		}
+
+		if expected, ok := c.Encoding[name]; ok && !matchesAny(expected, partType) {
+			errs = append(errs, violation(JSONPointer("", name), "content type must be %s, got %s", expected, partType)...)
+		}
+
+		values[name] = append(values[name], string(content))
+		partTypes[name] = partType
+	}
+
+	if len(errs) > 0 {
+		return nil, errs, nil
+	}
+
+	obj := formValues(c.Schema, values)
+
+	for name, partType := range partTypes {
+		if !IsJSONMediaType(partType) {
+			continue
+		}
+
+		var value interface{}
+
+		if err := json.Unmarshal([]byte(values[name][0]), &value); err != nil {
+			return nil, violation(JSONPointer("", name), "JSON is malformed: %v", err), nil
+		}
+
+		obj[name] = value
+	}
+
+	return Coerce(c.Schema, obj), nil, nil
+}
+
+// formValues returns object of form values, values of array properties are arrays and other properties have the first value
+func formValues(s Schema, values map[string][]string) map[string]interface{} {
+	var props map[string]Schema
+	if o, ok := Unwrap(s).(ObjectSchema); ok {
+		props = o.Properties
+	}
+
+	obj := make(map[string]interface{}, len(values))
+
+	for name, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+
+		if schemaKind(props[name]) == "array" {
+			obj[name] = list(v)
+
+			continue
+		}
+
+		obj[name] = v[0]
+	}
+
+	return obj
+}
+
+// matchesAny returns true if media type matches one of comma-separated media ranges, e.g. image/png, image/*
+func matchesAny(ranges, mediaType string) bool {
+	for _, r := range parseAccept(ranges) {
+		if r.specificity(mediaType) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
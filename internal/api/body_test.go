@@ -0,0 +1,247 @@
+package api_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+func TestUnsupportedMediaTypeError(t *testing.T) {
+	err := &api.UnsupportedMediaTypeError{ContentType: "text/html", MediaTypes: []string{"application/json", "text/plain"}}
+
+	require.EqualError(t, err, "content type must be one of application/json, text/plain, got text/html")
+}
+
+func multipartBody(t *testing.T, parts ...[3]string) (string, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	for _, p := range parts {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+p[0]+`"`)
+
+		if p[2] != "" {
+			h.Set("Content-Disposition", `form-data; name="`+p[0]+`"; filename="file"`)
+			h.Set("Content-Type", p[2])
+		}
+
+		part, err := w.CreatePart(h)
+		require.NoError(t, err)
+
+		_, err = part.Write([]byte(p[1]))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	return w.FormDataContentType(), buf.String()
+}
+
+func TestFindResponse_RequestBody(t *testing.T) {
+	form := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"name":   api.StringSchema{},
+			"age":    api.IntSchema{},
+			"tags":   api.ArraySchema{Type: api.StringSchema{}},
+			"avatar": api.StringSchema{Format: "binary"},
+			"meta":   api.ObjectSchema{Properties: map[string]api.Schema{"admin": api.BooleanSchema{}}},
+		},
+		Required: []string{"name"},
+	}
+
+	a := api.API{
+		Operations: []api.Operation{
+			{
+				Method: "POST",
+				Path:   "/users",
+				RequestBody: &api.RequestBody{
+					Required: true,
+					Content: []api.RequestContent{
+						{MediaType: "application/json", Schema: form},
+						{MediaType: "application/x-www-form-urlencoded", Schema: form},
+						{MediaType: "multipart/form-data", Schema: form, Encoding: map[string]string{"avatar": "image/png, image/jpeg"}},
+					},
+				},
+				Responses: []api.Response{{StatusCode: 201}},
+			},
+			{
+				Method: "PUT",
+				Path:   "/files",
+				RequestBody: &api.RequestBody{
+					Content: []api.RequestContent{
+						{MediaType: "application/octet-stream", Schema: api.StringSchema{Format: "binary"}},
+						{MediaType: "text/plain", Schema: api.StringSchema{MaxLength: func(v uint64) *uint64 { return &v }(3)}},
+					},
+				},
+				Responses: []api.Response{{StatusCode: 204}},
+			},
+			{
+				Method:    "POST",
+				Path:      "/ping",
+				Responses: []api.Response{{StatusCode: 200}},
+			},
+		},
+	}
+
+	multipartType, multipartData := multipartBody(t, [3]string{"name", "Elon"}, [3]string{"meta", `{"admin":true}`, "application/json"}, [3]string{"avatar", "png", "image/png"})
+	wrongPartType, wrongPartData := multipartBody(t, [3]string{"name", "Elon"}, [3]string{"avatar", "gif", "image/gif"})
+
+	tests := []struct {
+		name        string
+		path        string
+		method      string
+		contentType string
+		body        string
+		want        api.Response
+		err         error
+	}{
+		{
+			name:   "JSON without content type",
+			path:   "/users",
+			method: "POST",
+			body:   `{"name":"Elon"}`,
+			want:   api.Response{StatusCode: 201},
+		},
+		{
+			name:        "form",
+			path:        "/users",
+			method:      "POST",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=Elon&age=50&tags=a&tags=b",
+			want:        api.Response{StatusCode: 201},
+		},
+		{
+			name:        "form with wrong type",
+			path:        "/users",
+			method:      "POST",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "age=old",
+			err: api.ValidationErrors{
+				{Pointer: "/name", Message: "required property is missing"},
+				{Pointer: "/age", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name:        "multipart with file and JSON part",
+			path:        "/users",
+			method:      "POST",
+			contentType: multipartType,
+			body:        multipartData,
+			want:        api.Response{StatusCode: 201},
+		},
+		{
+			name:        "multipart with wrong content type of part",
+			path:        "/users",
+			method:      "POST",
+			contentType: wrongPartType,
+			body:        wrongPartData,
+			err:         api.ValidationErrors{{Pointer: "/avatar", Message: "content type must be image/png, image/jpeg, got image/gif"}},
+		},
+		{
+			name:        "multipart without boundary",
+			path:        "/users",
+			method:      "POST",
+			contentType: "multipart/form-data",
+			body:        "name=Elon",
+			err:         api.ValidationErrors{{Message: "multipart boundary is missing"}},
+		},
+		{
+			name:        "not declared content type",
+			path:        "/users",
+			method:      "POST",
+			contentType: "text/plain",
+			body:        "Elon",
+			err: &api.UnsupportedMediaTypeError{
+				ContentType: "text/plain",
+				MediaTypes:  []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data"},
+			},
+		},
+		{
+			name:   "required body",
+			path:   "/users",
+			method: "POST",
+			err:    api.ValidationErrors{{Message: "request body is required"}},
+		},
+		{
+			name:        "octet-stream",
+			path:        "/files",
+			method:      "PUT",
+			contentType: "application/octet-stream",
+			body:        "\x00\x01binary",
+			want:        api.Response{StatusCode: 204},
+		},
+		{
+			name:        "text",
+			path:        "/files",
+			method:      "PUT",
+			contentType: "text/plain; charset=utf-8",
+			body:        "long",
+			err:         api.ValidationErrors{{Message: "length must be less than or equal to 3"}},
+		},
+		{
+			name:   "optional body",
+			path:   "/files",
+			method: "PUT",
+			want:   api.Response{StatusCode: 204},
+		},
+		{
+			name:        "operation without request body",
+			path:        "/ping",
+			method:      "POST",
+			contentType: "application/json",
+			body:        "{broken",
+			want:        api.Response{StatusCode: 200},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.contentType != "" {
+				header.Set("Content-Type", tc.contentType)
+			}
+
+			got, err := a.FindResponse(api.FindResponseParams{
+				Path:   tc.path,
+				Method: tc.method,
+				Body:   io.NopCloser(strings.NewReader(tc.body)),
+				Header: header,
+			})
+
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestOperation_DecodeBody(t *testing.T) {
+	o := api.Operation{
+		RequestBody: &api.RequestBody{
+			Content: []api.RequestContent{
+				{
+					MediaType: "application/x-www-form-urlencoded",
+					Schema:    api.ObjectSchema{Properties: map[string]api.Schema{"age": api.IntSchema{}}},
+				},
+			},
+		},
+	}
+
+	got, err := o.DecodeBody("application/x-www-form-urlencoded", []byte("name=Elon&age=50"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"name": "Elon", "age": float64(50)}, got)
+
+	got, err = api.Operation{}.DecodeBody("", []byte(`{"name":"Elon"}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"name": "Elon"}, got)
+}
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
-
-	"github.com/neotoolkit/openapi"
+	"strings"
 
 	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
 )
 
 // SchemaTypeError -.
@@ -40,9 +42,7 @@ func ParseArrayExample(data interface{}) ([]interface{}, error) {
 	d, ok := data.([]interface{})
 	if ok {
 		res := make([]interface{}, len(d))
-		for k, v := range d {
-			res[k] = v.(map[string]interface{})
-		}
+		copy(res, d)
 
 		return res, nil
 	}
@@ -73,6 +73,22 @@ func ParseObjectExample(data interface{}) (map[string]interface{}, error) {
 	return nil, &ObjectExampleError{Data: data}
 }
 
+// toFloat64 converts number decoded from JSON or YAML specification to float64
+func toFloat64(data interface{}) (float64, bool) {
+	switch v := data.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	return 0, false
+}
+
 // RemoveTrailingSlash returns path without trailing slash
 func RemoveTrailingSlash(path string) string {
 	if len(path) > 0 && path[len(path)-1] == '/' {
@@ -86,33 +102,131 @@ type Builder struct {
 	OpenAPI    openapi.OpenAPI
 	Operations []Operation
 	Faker      faker.Faker
+	// ExamplePolicy is policy for response examples which do not match their schemas, empty policy is ExamplePolicyWarn
+	ExamplePolicy ExamplePolicy
+	// Warn is called with ExampleError for examples which are reported by policy
+	Warn func(err error)
+	// BasePath overrides base path of the first server of specification, / serves operations at root
+	BasePath string
+	// direction is side of exchange which schemas are converted for
+	direction direction
+	// response is location of response which schemas are converted for, examples of its schemas are checked by policy
+	response *ExampleError
 }
 
 // Build -.
 func (b *Builder) Build() (API, error) {
-	for path, method := range b.OpenAPI.Paths {
-		if err := b.Add(path, http.MethodGet, method.Get); err != nil {
-			return API{}, err
+	base, err := b.basePath()
+	if err != nil {
+		return API{}, err
+	}
+
+	paths := make([]string, 0, len(b.OpenAPI.Paths))
+	for path := range b.OpenAPI.Paths {
+		paths = append(paths, path)
+	}
+
+	// operations are ordered by path, so API is the same for every build
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		method := b.OpenAPI.Paths[path]
+
+		operations := []struct {
+			method    string
+			operation *openapi.Operation
+		}{
+			{method: http.MethodGet, operation: method.Get},
+			{method: http.MethodPost, operation: method.Post},
+			{method: http.MethodPut, operation: method.Put},
+			{method: http.MethodPatch, operation: method.Patch},
+			{method: http.MethodDelete, operation: method.Delete},
+			{method: http.MethodHead, operation: method.Head},
+			{method: http.MethodOptions, operation: method.Options},
+			{method: http.MethodTrace, operation: method.Trace},
 		}
 
-		if err := b.Add(path, http.MethodPost, method.Post); err != nil {
-			return API{}, err
+		for _, o := range operations {
+			operation, err := b.withParameters(o.operation, method.Parameters)
+			if err != nil {
+				return API{}, err
+			}
+
+			if err := b.Add(base+path, o.method, operation); err != nil {
+				return API{}, err
+			}
 		}
+	}
+
+	return NewAPI(b.Operations), nil
+}
+
+// basePath returns base path which operations are served under, it is empty for root
+func (b *Builder) basePath() (string, error) {
+	if b.BasePath != "" {
+		return strings.TrimRight(b.BasePath, "/"), nil
+	}
 
-		if err := b.Add(path, http.MethodPut, method.Put); err != nil {
-			return API{}, err
+	return b.OpenAPI.BasePath()
+}
+
+// withParameters returns copy of operation with path item parameters which are not overridden by operation
+func (b *Builder) withParameters(o *openapi.Operation, common openapi.Parameters) (*openapi.Operation, error) {
+	if nil == o || len(common) == 0 {
+		return o, nil
+	}
+
+	own, err := b.resolveParameters(o.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	inherited, err := b.resolveParameters(common)
+	if err != nil {
+		return nil, err
+	}
+
+	operation := *o
+	operation.Parameters = make(openapi.Parameters, 0, len(inherited)+len(own))
+
+	for _, param := range inherited {
+		overridden := false
+
+		for _, p := range own {
+			if p.Name == param.Name && p.In == param.In {
+				overridden = true
+
+				break
+			}
 		}
 
-		if err := b.Add(path, http.MethodPatch, method.Patch); err != nil {
-			return API{}, err
+		if !overridden {
+			operation.Parameters = append(operation.Parameters, param)
 		}
+	}
+
+	operation.Parameters = append(operation.Parameters, own...)
+
+	return &operation, nil
+}
+
+func (b *Builder) resolveParameters(params openapi.Parameters) (openapi.Parameters, error) {
+	res := make(openapi.Parameters, len(params))
+
+	for i, p := range params {
+		if p.Ref != "" {
+			param, err := b.OpenAPI.LookupParameter(p.Ref)
+			if err != nil {
+				return nil, fmt.Errorf("resolve reference: %w", err)
+			}
 
-		if err := b.Add(path, http.MethodDelete, method.Delete); err != nil {
-			return API{}, err
+			p = param
 		}
+
+		res[i] = p
 	}
 
-	return API{Operations: b.Operations}, nil
+	return res, nil
 }
 
 // Add -.
@@ -142,79 +256,259 @@ func (b *Builder) Set(path, method string, o *openapi.Operation) (Operation, err
 		return operation, nil
 	}
 
-	body, ok := o.RequestBody.Content["application/json"]
-	if ok {
-		var s openapi.Schema
+	operation.Proxy = o.Proxy
 
-		if body.Schema.Ref != "" {
-			schema, err := b.OpenAPI.LookupByReference(body.Schema.Ref)
-			if err != nil {
-				return Operation{}, fmt.Errorf("resolve reference: %w", err)
-			}
+	params, err := b.resolveParameters(o.Parameters)
+	if err != nil {
+		return Operation{}, err
+	}
 
-			s = schema
-		} else {
-			s = body.Schema
+	for _, p := range params {
+		param, err := b.convertParameter(p)
+		if err != nil {
+			return Operation{}, err
 		}
 
-		operation.Body = make(map[string]FieldType, len(s.Properties))
-
-		for _, v := range s.Required {
-			operation.Body[v] = FieldType{
-				Required: true,
-			}
-		}
+		operation.Parameters = append(operation.Parameters, param)
+	}
 
-		for k, v := range s.Properties {
-			operation.Body[k] = FieldType{
-				Required: operation.Body[k].Required,
-				Type:     v.Type,
-			}
-		}
+	requestBody, err := b.convertRequestBody(o.RequestBody)
+	if err != nil {
+		return Operation{}, err
 	}
 
+	operation.RequestBody = requestBody
+
 	for code, resp := range o.Responses {
 		statusCode, err := strconv.Atoi(code)
 		if err != nil {
 			return Operation{}, err
 		}
 
-		content, ok := resp.Content["application/json"]
-		if !ok {
-			operation.Responses = append(operation.Responses, Response{
-				StatusCode: statusCode,
-			})
+		responses, err := b.convertResponse(method, path, statusCode, resp)
+		if err != nil {
+			return Operation{}, err
+		}
 
-			continue
+		operation.Responses = append(operation.Responses, responses...)
+	}
+
+	// responses are ordered, so the same response is selected on every request
+	sort.SliceStable(operation.Responses, func(i, j int) bool {
+		a, b := operation.Responses[i], operation.Responses[j]
+
+		if a.StatusCode != b.StatusCode {
+			return a.StatusCode < b.StatusCode
+		}
+
+		return a.MediaType < b.MediaType
+	})
+
+	return operation, nil
+}
+
+// convertRequestBody returns request body of every media type, nil if there is no media type
+func (b *Builder) convertRequestBody(body openapi.RequestBody) (*RequestBody, error) {
+	mediaTypes := make([]string, 0, len(body.Content))
+	for mediaType := range body.Content {
+		if body.Content[mediaType] != nil {
+			mediaTypes = append(mediaTypes, mediaType)
 		}
+	}
+
+	if len(mediaTypes) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(mediaTypes)
+
+	res := &RequestBody{
+		Required: body.Required,
+		Content:  make([]RequestContent, 0, len(mediaTypes)),
+	}
+
+	for _, mediaType := range mediaTypes {
+		mt := body.Content[mediaType]
 
-		example := openapi.ExampleToResponse(content.Example)
+		schema, err := b.in(directionRequest).convertSubschema(mt.Schema)
+		if err != nil {
+			return nil, err
+		}
 
-		examples := make(map[string]interface{}, len(content.Examples)+1)
+		content := RequestContent{
+			MediaType: mediaType,
+			Schema:    schema,
+		}
 
-		if len(content.Examples) > 0 {
-			for key, e := range content.Examples {
-				examples[key] = openapi.ExampleToResponse(e.Value)
+		for name, e := range mt.Encoding {
+			if nil == e || e.ContentType == "" {
+				continue
 			}
 
-			examples[""] = openapi.ExampleToResponse(content.Examples[content.Examples.GetKeys()[0]].Value)
+			if nil == content.Encoding {
+				content.Encoding = make(map[string]string, len(mt.Encoding))
+			}
+
+			content.Encoding[name] = e.ContentType
+		}
+
+		res.Content = append(res.Content, content)
+	}
+
+	return res, nil
+}
+
+// convertContentSchema returns schema of media type, schema without type allows any value if media type is not JSON,
+// e.g. application/octet-stream, root of XML is named by referenced component
+func (b *Builder) convertContentSchema(mediaType string, s openapi.Schema) (Schema, error) {
+	if !IsJSONMediaType(mediaType) && isEmptySchema(s) {
+		return AnySchema{}, nil
+	}
+
+	schema, err := b.convertSchema(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsXMLMediaType(mediaType) && s.Ref != "" {
+		schema = withXMLName(schema, s.Ref)
+	}
+
+	return schema, nil
+}
+
+// isEmptySchema returns true if schema has no type, reference, faker and composition
+func isEmptySchema(s openapi.Schema) bool {
+	return s.Ref == "" && s.Faker == "" && schemaType(s) == "" && len(s.AllOf)+len(s.OneOf)+len(s.AnyOf) == 0
+}
+
+// IsJSONMediaType returns true for application/json and media types with +json suffix, e.g. application/problem+json
+func IsJSONMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// convertResponse returns response for every media type of response, response without body if there is no media type
+func (b *Builder) convertResponse(method, path string, statusCode int, resp *openapi.Response) ([]Response, error) {
+	var content openapi.Content
+	if resp != nil {
+		content = resp.Content
+	}
+
+	headers, err := b.convertHeaders(method, path, statusCode, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		if content[mediaType] != nil {
+			mediaTypes = append(mediaTypes, mediaType)
 		}
+	}
+
+	if len(mediaTypes) == 0 {
+		return []Response{{StatusCode: statusCode, Headers: headers}}, nil
+	}
+
+	sort.Strings(mediaTypes)
+
+	res := make([]Response, 0, len(mediaTypes))
+
+	// writeOnly properties are omitted from schemas and examples of responses
+	rb := b.in(directionResponse).at(&ExampleError{Method: method, Path: path, StatusCode: statusCode})
 
-		schema, err := b.convertSchema(content.Schema)
+	for _, mediaType := range mediaTypes {
+		mt := content[mediaType]
+
+		schema, err := rb.convertContentSchema(mediaType, mt.Schema)
 		if err != nil {
-			return Operation{}, err
+			return nil, err
 		}
 
-		operation.Responses = append(operation.Responses, Response{
+		var example interface{}
+
+		if mt.Example != nil {
+			value := rb.stripWriteOnly(mt.Schema, mt.Example)
+
+			ok, err := b.checkExample(&ExampleError{Method: method, Path: path, StatusCode: statusCode}, schema, value)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				example = openapi.ExampleToResponse(value)
+			}
+		}
+
+		examples := make(map[string]interface{}, len(mt.Examples)+1)
+
+		for _, key := range mt.Examples.GetKeys() {
+			value := rb.stripWriteOnly(mt.Schema, mt.Examples[key].Value)
+
+			ok, err := b.checkExample(&ExampleError{Method: method, Path: path, StatusCode: statusCode, Key: key}, schema, value)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			examples[key] = openapi.ExampleToResponse(value)
+
+			// first accepted example is served by default
+			if _, ok := examples[""]; !ok {
+				examples[""] = examples[key]
+			}
+		}
+
+		res = append(res, Response{
 			StatusCode: statusCode,
-			MediaType:  "application/json",
+			MediaType:  mediaType,
 			Schema:     schema,
 			Example:    example,
 			Examples:   examples,
+			Headers:    headers,
 		})
 	}
 
-	return operation, nil
+	return res, nil
+}
+
+func (b *Builder) convertParameter(p openapi.Parameter) (Parameter, error) {
+	param := Parameter{
+		Name:     p.Name,
+		In:       p.In,
+		Required: p.Required || p.In == InPath,
+		Style:    p.Style,
+		Schema:   AnySchema{},
+	}
+
+	if param.Style == "" {
+		param.Style = DefaultStyle(p.In)
+	}
+
+	if p.Explode != nil {
+		param.Explode = *p.Explode
+	} else {
+		param.Explode = param.Style == StyleForm
+	}
+
+	if p.Schema != nil {
+		// parameter schema is used only for validation, so examples are not generated
+		validator := &Builder{OpenAPI: b.OpenAPI}
+
+		schema, err := validator.convertSubschema(*p.Schema)
+		if err != nil {
+			return Parameter{}, err
+		}
+
+		param.Schema = schema
+	}
+
+	return param, nil
 }
 
 func (b *Builder) convertSchema(s openapi.Schema) (Schema, error) {
@@ -228,46 +522,381 @@ func (b *Builder) convertSchema(s openapi.Schema) (Schema, error) {
 	}
 
 	if s.Faker != "" {
-		return FakerSchema{Example: b.Faker.ByName(s.Faker)}, nil
+		return FakerSchema{Example: b.Faker.ByName(s.Faker), Name: s.Faker}, nil
 	}
 
-	switch s.Type {
+	var (
+		schema Schema
+		err    error
+	)
+
+	switch {
+	case len(s.AllOf) > 0:
+		schema, err = b.convertAllOf(s)
+	case len(s.OneOf) > 0:
+		schemas, d, e := b.convertBranches(s.OneOf, s.Discriminator)
+		schema, err = OneOfSchema{Schemas: schemas, Discriminator: d}, e
+	case len(s.AnyOf) > 0:
+		schemas, d, e := b.convertBranches(s.AnyOf, s.Discriminator)
+		schema, err = AnyOfSchema{Schemas: schemas, Discriminator: d}, e
+	default:
+		schema, err = b.convertType(s)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Nullable {
+		return NullableSchema{Schema: schema}, nil
+	}
+
+	return schema, nil
+}
+
+// generates returns true if examples are generated for schemas without example, it requires faker with generator
+func (b *Builder) generates() bool {
+	return b.Faker.Generator != nil
+}
+
+// generateItems returns example of array which respects minItems and maxItems
+// Example is empty if array has single item, the item is example of items schema in this case
+func (b *Builder) generateItems(s openapi.Schema) ([]interface{}, error) {
+	count := itemsCount(s.MinItems, s.MaxItems)
+
+	if count == 1 {
+		return []interface{}{}, nil
+	}
+
+	items := make([]interface{}, 0, count)
+
+	// items schema is converted for every item to get different generated values
+	for i := uint64(0); i < count; i++ {
+		schema, err := b.convertSubschema(*s.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, schema.ExampleValue())
+	}
+
+	return items, nil
+}
+
+// convertAllOf merges allOf objects to one object, other schemas are combined to AllOfSchema
+func (b *Builder) convertAllOf(s openapi.Schema) (Schema, error) {
+	schemas := make([]Schema, 0, len(s.AllOf)+1)
+
+	for _, member := range s.AllOf {
+		schema, err := b.convertSubschema(*member)
+		if err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	// keywords next to allOf are one more member, e.g. properties added to base object
+	if schemaType(s) != "" {
+		own := s
+		own.AllOf = nil
+
+		schema, err := b.convertType(own)
+		if err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	if obj, ok := mergeObjects(schemas); ok {
+		return obj, nil
+	}
+
+	return AllOfSchema{Schemas: schemas}, nil
+}
+
+func mergeObjects(schemas []Schema) (ObjectSchema, bool) {
+	merged := ObjectSchema{
+		Properties: map[string]Schema{},
+		Example:    map[string]interface{}{},
+	}
+
+	required := map[string]bool{}
+
+	for _, s := range schemas {
+		if _, ok := s.(AnySchema); ok {
+			continue
+		}
+
+		obj, ok := s.(ObjectSchema)
+		if !ok {
+			return ObjectSchema{}, false
+		}
+
+		for k, v := range obj.Properties {
+			merged.Properties[k] = v
+		}
+
+		for k, v := range obj.Example {
+			merged.Example[k] = v
+		}
+
+		for _, k := range obj.Required {
+			if !required[k] {
+				required[k] = true
+				merged.Required = append(merged.Required, k)
+			}
+		}
+
+		if obj.AdditionalProperties != nil {
+			merged.AdditionalProperties = obj.AdditionalProperties
+		}
+
+		if obj.MinProperties != nil {
+			merged.MinProperties = obj.MinProperties
+		}
+
+		if obj.MaxProperties != nil {
+			merged.MaxProperties = obj.MaxProperties
+		}
+	}
+
+	return merged, true
+}
+
+// convertBranches converts oneOf or anyOf schemas and maps discriminator values to them
+func (b *Builder) convertBranches(branches []*openapi.Schema, d *openapi.Discriminator) ([]Schema, *Discriminator, error) {
+	schemas := make([]Schema, len(branches))
+
+	for i, branch := range branches {
+		schema, err := b.convertSubschema(*branch)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		schemas[i] = schema
+	}
+
+	if nil == d {
+		return schemas, nil, nil
+	}
+
+	discriminator := &Discriminator{
+		PropertyName: d.PropertyName,
+		Mapping:      make(map[string]int, len(branches)),
+	}
+
+	for i, branch := range branches {
+		if branch.Ref == "" {
+			continue
+		}
+
+		name := schemaName(branch.Ref)
+		mapped := false
+
+		for value, ref := range d.Mapping {
+			if schemaName(ref) == name {
+				discriminator.Mapping[value] = i
+				mapped = true
+			}
+		}
+
+		// schema name is implicit discriminator value
+		if !mapped {
+			discriminator.Mapping[name] = i
+		}
+	}
+
+	return schemas, discriminator, nil
+}
+
+func schemaName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// convertSubschema converts schema of property, items or request body, empty schema allows any value
+func (b *Builder) convertSubschema(s openapi.Schema) (Schema, error) {
+	if isEmptySchema(s) {
+		return AnySchema{}, nil
+	}
+
+	return b.convertSchema(s)
+}
+
+// schemaType returns type of schema, it is inferred from keywords if type is omitted
+func schemaType(s openapi.Schema) string {
+	switch {
+	case s.Type != "":
+		return s.Type
+	case s.Properties != nil || s.AdditionalProperties != nil:
+		return "object"
+	case s.Items != nil:
+		return "array"
+	}
+
+	return ""
+}
+
+func (b *Builder) convertType(s openapi.Schema) (Schema, error) {
+	switch schemaType(s) {
 	case "boolean":
-		val, _ := s.Example.(bool)
-		return BooleanSchema{Example: val}, nil
+		schema := BooleanSchema{XML: convertXML(s.XML)}
+
+		example, err := b.schemaExample(schema, s.Example)
+		if err != nil {
+			return nil, err
+		}
+
+		switch val := example.(type) {
+		case bool:
+			schema.Example = val
+		case nil:
+			if b.generates() {
+				schema.Example = schema.generate(b.Faker)
+				schema.Dynamic = true
+			}
+		}
+
+		return schema, nil
 	case "integer":
-		val, _ := s.Example.(int64)
-		return IntSchema{Example: val}, nil
+		schema := IntSchema{
+			Enum:             s.Enum,
+			Minimum:          s.Minimum,
+			Maximum:          s.Maximum,
+			ExclusiveMinimum: s.ExclusiveMinimum,
+			ExclusiveMaximum: s.ExclusiveMaximum,
+			MultipleOf:       s.MultipleOf,
+			XML:              convertXML(s.XML),
+		}
+
+		example, err := b.schemaExample(schema, s.Example)
+		if err != nil {
+			return nil, err
+		}
+
+		val, ok := toFloat64(example)
+
+		switch {
+		case ok:
+			schema.Example = int64(val)
+		case nil == example && b.generates():
+			schema.Example = schema.generate(b.Faker)
+			schema.Dynamic = true
+		}
+
+		return schema, nil
 	case "number":
-		val, _ := s.Example.(float64)
-		return FloatSchema{Example: val}, nil
+		schema := FloatSchema{
+			Enum:             s.Enum,
+			Minimum:          s.Minimum,
+			Maximum:          s.Maximum,
+			ExclusiveMinimum: s.ExclusiveMinimum,
+			ExclusiveMaximum: s.ExclusiveMaximum,
+			MultipleOf:       s.MultipleOf,
+			XML:              convertXML(s.XML),
+		}
+
+		example, err := b.schemaExample(schema, s.Example)
+		if err != nil {
+			return nil, err
+		}
+
+		val, ok := toFloat64(example)
+
+		switch {
+		case ok:
+			schema.Example = val
+		case nil == example && b.generates():
+			schema.Example = schema.generate(b.Faker)
+			schema.Dynamic = true
+		}
+
+		return schema, nil
 	case "string":
-		val, _ := s.Example.(string)
-		return StringSchema{Example: val}, nil
+		schema := StringSchema{
+			Format:    s.Format,
+			Enum:      s.Enum,
+			MinLength: s.MinLength,
+			MaxLength: s.MaxLength,
+			Pattern:   s.Pattern,
+			XML:       convertXML(s.XML),
+		}
+
+		example, err := b.schemaExample(schema, s.Example)
+		if err != nil {
+			return nil, err
+		}
+
+		switch val := example.(type) {
+		case string:
+			schema.Example = val
+		case nil:
+			if b.generates() {
+				schema.Example = schema.generate(b.Faker)
+				schema.Dynamic = true
+			}
+		}
+
+		return schema, nil
 	case "array":
 		if nil == s.Items {
 			return nil, ErrEmptyItems
 		}
 
-		itemsSchema, err := b.convertSchema(*s.Items)
+		itemsSchema, err := b.convertSubschema(*s.Items)
 		if err != nil {
 			return nil, err
 		}
 
+		schema := ArraySchema{
+			Type:        itemsSchema,
+			MinItems:    s.MinItems,
+			MaxItems:    s.MaxItems,
+			UniqueItems: s.UniqueItems,
+			XML:         convertXML(s.XML),
+		}
+
 		arrExample, err := ParseArrayExample(s.Example)
 		if err != nil {
 			return nil, err
 		}
 
-		return ArraySchema{
-			Type:    itemsSchema,
-			Example: arrExample,
-		}, nil
+		example, err := b.schemaExample(schema, s.Example)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Dynamic = nil == example && b.generates()
+
+		switch {
+		case schema.Dynamic:
+			arrExample, err = b.generateItems(s)
+			if err != nil {
+				return nil, err
+			}
+		case nil == example:
+			arrExample = []interface{}{}
+		}
+
+		schema.Example = arrExample
+
+		return schema, nil
 	case "object":
-		obj := ObjectSchema{Properties: make(map[string]Schema, len(s.Properties))}
+		obj := ObjectSchema{
+			Properties:    make(map[string]Schema, len(s.Properties)),
+			Required:      b.required(s),
+			MinProperties: s.MinProperties,
+			MaxProperties: s.MaxProperties,
+			XML:           convertXML(s.XML),
+		}
 
 		for key, prop := range s.Properties {
-			propSchema, err := b.convertSchema(*prop)
+			if b.omits(*prop) {
+				continue
+			}
+
+			propSchema, err := b.convertSubschema(*prop)
 			if err != nil {
 				return nil, err
 			}
@@ -275,11 +904,31 @@ func (b *Builder) convertSchema(s openapi.Schema) (Schema, error) {
 			obj.Properties[key] = propSchema
 		}
 
+		additional, err := b.convertAdditionalProperties(s.AdditionalProperties)
+		if err != nil {
+			return nil, err
+		}
+
+		obj.AdditionalProperties = additional
+
 		objExample, err := ParseObjectExample(s.Example)
 		if err != nil {
 			return nil, err
 		}
 
+		if s.Example != nil {
+			objExample, _ = b.stripWriteOnly(s, objExample).(map[string]interface{})
+
+			example, err := b.schemaExample(obj, objExample)
+			if err != nil {
+				return nil, err
+			}
+
+			if nil == example {
+				objExample = map[string]interface{}{}
+			}
+		}
+
 		obj.Example = objExample
 
 		return obj, nil
@@ -287,3 +936,16 @@ func (b *Builder) convertSchema(s openapi.Schema) (Schema, error) {
 		return nil, &SchemaTypeError{SchemaType: s.Type}
 	}
 }
+
+func (b *Builder) convertAdditionalProperties(a *openapi.AdditionalProperties) (Schema, error) {
+	switch {
+	case nil == a:
+		return nil, nil
+	case !a.Allowed:
+		return FalseSchema{}, nil
+	case a.Schema != nil:
+		return b.convertSubschema(*a.Schema)
+	}
+
+	return nil, nil
+}
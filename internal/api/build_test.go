@@ -6,10 +6,10 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/neotoolkit/openapi"
 	"github.com/stretchr/testify/require"
 
 	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
 )
 
 func TestSchemaTypeError(t *testing.T) {
@@ -55,6 +55,12 @@ func TestParseArrayExample(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "array of scalars",
+			data: []interface{}{"a", uint64(1)},
+			want: []interface{}{"a", uint64(1)},
+			err:  nil,
+		},
 		{
 			name: "not array",
 			data: "string",
@@ -164,7 +170,7 @@ func TestBuilder_Build(t *testing.T) {
 		{
 			name:    "",
 			builder: api.Builder{},
-			want:    api.API{},
+			want:    api.NewAPI(nil),
 			err:     nil,
 		},
 		{
@@ -178,16 +184,33 @@ func TestBuilder_Build(t *testing.T) {
 					},
 				},
 			},
-			want: api.API{
-				Operations: []api.Operation{
-					{
-						Method:    "GET",
-						Path:      "test",
-						Body:      map[string]api.FieldType(nil),
-						Responses: []api.Response(nil),
+			want: api.NewAPI([]api.Operation{
+				{
+					Method:    "GET",
+					Path:      "test",
+					Responses: []api.Response(nil),
+				},
+			}),
+			err: nil,
+		},
+		{
+			name: "HEAD, OPTIONS and TRACE",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Paths: map[string]*openapi.Path{
+						"test": {
+							Head:    &openapi.Operation{},
+							Options: &openapi.Operation{},
+							Trace:   &openapi.Operation{},
+						},
 					},
 				},
 			},
+			want: api.NewAPI([]api.Operation{
+				{Method: "HEAD", Path: "test"},
+				{Method: "OPTIONS", Path: "test"},
+				{Method: "TRACE", Path: "test"},
+			}),
 			err: nil,
 		},
 		{
@@ -223,16 +246,13 @@ func TestBuilder_Build(t *testing.T) {
 					},
 				},
 			},
-			want: api.API{
-				Operations: []api.Operation{
-					{
-						Method:    "POST",
-						Path:      "test",
-						Body:      map[string]api.FieldType(nil),
-						Responses: []api.Response(nil),
-					},
+			want: api.NewAPI([]api.Operation{
+				{
+					Method:    "POST",
+					Path:      "test",
+					Responses: []api.Response(nil),
 				},
-			},
+			}),
 			err: nil,
 		},
 		{
@@ -270,16 +290,13 @@ func TestBuilder_Build(t *testing.T) {
 					},
 				},
 			},
-			want: api.API{
-				Operations: []api.Operation{
-					{
-						Method:    "PUT",
-						Path:      "test",
-						Body:      map[string]api.FieldType(nil),
-						Responses: []api.Response(nil),
-					},
+			want: api.NewAPI([]api.Operation{
+				{
+					Method:    "PUT",
+					Path:      "test",
+					Responses: []api.Response(nil),
 				},
-			},
+			}),
 			err: nil,
 		},
 		{
@@ -317,16 +334,13 @@ func TestBuilder_Build(t *testing.T) {
 					},
 				},
 			},
-			want: api.API{
-				Operations: []api.Operation{
-					{
-						Method:    "PATCH",
-						Path:      "test",
-						Body:      map[string]api.FieldType(nil),
-						Responses: []api.Response(nil),
-					},
+			want: api.NewAPI([]api.Operation{
+				{
+					Method:    "PATCH",
+					Path:      "test",
+					Responses: []api.Response(nil),
 				},
-			},
+			}),
 			err: nil,
 		},
 		{
@@ -364,16 +378,13 @@ func TestBuilder_Build(t *testing.T) {
 					},
 				},
 			},
-			want: api.API{
-				Operations: []api.Operation{
-					{
-						Method:    "DELETE",
-						Path:      "test",
-						Body:      map[string]api.FieldType(nil),
-						Responses: []api.Response(nil),
-					},
+			want: api.NewAPI([]api.Operation{
+				{
+					Method:    "DELETE",
+					Path:      "test",
+					Responses: []api.Response(nil),
 				},
-			},
+			}),
 			err: nil,
 		},
 		{
@@ -398,6 +409,112 @@ func TestBuilder_Build(t *testing.T) {
 				Err:  strconv.ErrSyntax,
 			},
 		},
+		{
+			name: "path item parameters",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Paths: map[string]*openapi.Path{
+						"/users/{userId}": {
+							Parameters: openapi.Parameters{
+								{Name: "userId", In: "path", Schema: &openapi.Schema{Type: "string"}},
+								{Ref: "#/components/parameters/Verbose"},
+							},
+							Get: &openapi.Operation{
+								Parameters: openapi.Parameters{
+									{Name: "userId", In: "path", Schema: &openapi.Schema{Type: "integer"}},
+								},
+							},
+						},
+					},
+					Components: openapi.Components{
+						Parameters: map[string]*openapi.Parameter{
+							"Verbose": {Name: "verbose", In: "query", Schema: &openapi.Schema{Type: "boolean"}},
+						},
+					},
+				},
+			},
+			want: api.NewAPI([]api.Operation{
+				{
+					Method: "GET",
+					Path:   "/users/{userId}",
+					Parameters: []api.Parameter{
+						{Name: "verbose", In: "query", Style: "form", Explode: true, Schema: api.BooleanSchema{}},
+						{Name: "userId", In: "path", Required: true, Style: "simple", Schema: api.IntSchema{}},
+					},
+				},
+			}),
+			err: nil,
+		},
+		{
+			name: "server base path",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Servers: openapi.Servers{
+						{
+							URL: "https://{env}.example.com/{version}/",
+							Variables: map[string]*openapi.ServerVariable{
+								"env":     {Default: "api"},
+								"version": {Enum: []string{"v1", "v2"}, Default: "v2"},
+							},
+						},
+					},
+					Paths: map[string]*openapi.Path{
+						"/users": {
+							Get: &openapi.Operation{},
+						},
+					},
+				},
+			},
+			want: api.NewAPI([]api.Operation{
+				{Method: "GET", Path: "/v2/users"},
+			}),
+			err: nil,
+		},
+		{
+			name: "base path instead of server base path",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Servers: openapi.Servers{{URL: "/v2"}},
+					Paths: map[string]*openapi.Path{
+						"/users": {
+							Get: &openapi.Operation{},
+						},
+					},
+				},
+				BasePath: "/",
+			},
+			want: api.NewAPI([]api.Operation{
+				{Method: "GET", Path: "/users"},
+			}),
+			err: nil,
+		},
+		{
+			name: "undefined server variable",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Servers: openapi.Servers{{URL: "/{version}"}},
+				},
+			},
+			want: api.API{},
+			err:  &openapi.ServerVariableError{URL: "/{version}", Name: "version"},
+		},
+		{
+			name: "Wrong parameter reference",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Paths: map[string]*openapi.Path{
+						"test": {
+							Parameters: openapi.Parameters{
+								{Ref: "#/components/parameters/Unknown"},
+							},
+							Get: &openapi.Operation{},
+						},
+					},
+				},
+			},
+			want: api.API{},
+			err:  fmt.Errorf("resolve reference: %w", &openapi.ParameterError{Ref: "#/components/parameters/Unknown"}),
+		},
 	}
 
 	for _, tc := range tests {
@@ -529,14 +646,63 @@ func TestBuilder_Set(t *testing.T) {
 				},
 			},
 			want: api.Operation{
-				Body: map[string]api.FieldType{
-					"field": {
-						Required: true,
-						Type:     "",
+				RequestBody: &api.RequestBody{
+					Content: []api.RequestContent{
+						{
+							MediaType: "application/json",
+							Schema: api.ObjectSchema{
+								Properties: map[string]api.Schema{
+									"prop": api.AnySchema{},
+								},
+								Example:  map[string]interface{}{},
+								Required: []string{"field"},
+							},
+						},
 					},
-					"prop": {
-						Required: false,
-						Type:     "",
+				},
+			},
+			err: nil,
+		},
+		{
+			name:    "request body constraints",
+			builder: api.Builder{},
+			path:    "",
+			method:  "",
+			operation: &openapi.Operation{
+				RequestBody: openapi.RequestBody{
+					Content: map[string]*openapi.MediaType{
+						"application/json": {
+							Schema: openapi.Schema{
+								Properties: map[string]*openapi.Schema{
+									"tags": {
+										Items:    &openapi.Schema{Type: "string", Enum: []interface{}{"a", "b"}},
+										Nullable: true,
+									},
+								},
+								AdditionalProperties: &openapi.AdditionalProperties{Allowed: false},
+							},
+						},
+					},
+				},
+			},
+			want: api.Operation{
+				RequestBody: &api.RequestBody{
+					Content: []api.RequestContent{
+						{
+							MediaType: "application/json",
+							Schema: api.ObjectSchema{
+								Properties: map[string]api.Schema{
+									"tags": api.NullableSchema{
+										Schema: api.ArraySchema{
+											Type:    api.StringSchema{Enum: []interface{}{"a", "b"}},
+											Example: []interface{}{},
+										},
+									},
+								},
+								Example:              map[string]interface{}{},
+								AdditionalProperties: api.FalseSchema{},
+							},
+						},
 					},
 				},
 			},
@@ -580,6 +746,124 @@ func TestBuilder_Set(t *testing.T) {
 			want: api.Operation{},
 			err:  &api.SchemaTypeError{SchemaType: ""},
 		},
+		{
+			name:    "numeric examples from JSON and YAML",
+			builder: api.Builder{},
+			path:    "",
+			method:  "",
+			operation: &openapi.Operation{
+				Responses: openapi.Responses{
+					"200": {
+						Content: map[string]*openapi.MediaType{
+							"application/json": {
+								Schema: openapi.Schema{
+									Type: "object",
+									Properties: map[string]*openapi.Schema{
+										"yaml": {Type: "integer", Example: uint64(42)},
+										"json": {Type: "integer", Example: float64(42)},
+										"rate": {Type: "number", Example: uint64(4)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: api.Operation{
+				Responses: []api.Response{
+					{
+						StatusCode: http.StatusOK,
+						MediaType:  "application/json",
+						Schema: api.ObjectSchema{
+							Properties: map[string]api.Schema{
+								"yaml": api.IntSchema{Example: 42},
+								"json": api.IntSchema{Example: 42},
+								"rate": api.FloatSchema{Example: 4},
+							},
+							Example: map[string]interface{}{},
+						},
+						Examples: map[string]interface{}{},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "schema composition",
+			builder: api.Builder{
+				OpenAPI: openapi.OpenAPI{
+					Components: openapi.Components{
+						Schemas: openapi.Schemas{
+							"Pet": {
+								Type:       "object",
+								Properties: openapi.Schemas{"name": {Type: "string"}},
+								Required:   []string{"name"},
+							},
+							"Cat": {
+								AllOf: []*openapi.Schema{
+									{Ref: "#/components/schemas/Pet"},
+									{Properties: openapi.Schemas{"meow": {Type: "boolean"}}},
+								},
+							},
+							"Dog": {
+								AllOf: []*openapi.Schema{{Ref: "#/components/schemas/Pet"}},
+							},
+						},
+					},
+				},
+			},
+			operation: &openapi.Operation{
+				RequestBody: openapi.RequestBody{
+					Content: map[string]*openapi.MediaType{
+						"application/json": {
+							Schema: openapi.Schema{
+								OneOf: []*openapi.Schema{
+									{Ref: "#/components/schemas/Cat"},
+									{Ref: "#/components/schemas/Dog"},
+								},
+								Discriminator: &openapi.Discriminator{
+									PropertyName: "petType",
+									Mapping:      map[string]string{"cat": "#/components/schemas/Cat"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: api.Operation{
+				RequestBody: &api.RequestBody{
+					Content: []api.RequestContent{
+						{
+							MediaType: "application/json",
+							Schema: api.OneOfSchema{
+								Schemas: []api.Schema{
+									api.ObjectSchema{
+										Properties: map[string]api.Schema{
+											"name": api.StringSchema{},
+											"meow": api.BooleanSchema{},
+										},
+										Example:  map[string]interface{}{},
+										Required: []string{"name"},
+									},
+									api.ObjectSchema{
+										Properties: map[string]api.Schema{
+											"name": api.StringSchema{},
+										},
+										Example:  map[string]interface{}{},
+										Required: []string{"name"},
+									},
+								},
+								Discriminator: &api.Discriminator{
+									PropertyName: "petType",
+									Mapping:      map[string]int{"cat": 0, "Dog": 1},
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
 	}
 
 	for _, tc := range tests {
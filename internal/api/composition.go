@@ -0,0 +1,213 @@
+package api
+
+import (
+	"sort"
+)
+
+// AllOfSchema is schema which value must be valid against all schemas
+type AllOfSchema struct {
+	Schemas []Schema
+}
+
+// ExampleValue returns merged example of objects, otherwise example of the first schema
+func (a AllOfSchema) ExampleValue() interface{} {
+	values := make([]interface{}, len(a.Schemas))
+
+	for i, s := range a.Schemas {
+		values[i] = s.ExampleValue()
+	}
+
+	return mergeExamples(values)
+}
+
+// mergeExamples returns merged objects, the first value is returned if there are no objects
+func mergeExamples(values []interface{}) interface{} {
+	var example interface{}
+
+	merged := map[string]interface{}{}
+
+	for _, value := range values {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			if nil == example {
+				example = value
+			}
+
+			continue
+		}
+
+		for k, v := range obj {
+			merged[k] = v
+		}
+
+		example = merged
+	}
+
+	return example
+}
+
+// Validate -.
+func (a AllOfSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	for _, s := range a.Schemas {
+		errs = append(errs, s.Validate(pointer, value)...)
+	}
+
+	return errs
+}
+
+// Discriminator selects schema of oneOf or anyOf by value of object property
+type Discriminator struct {
+	PropertyName string
+	// Mapping is index of schema by property value
+	Mapping map[string]int
+}
+
+// value returns the first property value which is mapped to schema
+func (d *Discriminator) value(index int) (string, bool) {
+	values := make([]string, 0, len(d.Mapping))
+
+	for v, i := range d.Mapping {
+		if i == index {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 0 {
+		return "", false
+	}
+
+	sort.Strings(values)
+
+	return values[0], true
+}
+
+// schema returns index of schema by discriminator property of value
+func (d *Discriminator) schema(pointer string, value interface{}) (int, ValidationErrors) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return -1, nil
+	}
+
+	property, ok := obj[d.PropertyName]
+	if !ok {
+		return -1, violation(JSONPointer(pointer, d.PropertyName), "required property is missing")
+	}
+
+	name, _ := property.(string)
+
+	i, ok := d.Mapping[name]
+	if !ok {
+		return -1, violation(JSONPointer(pointer, d.PropertyName), "unknown discriminator value %v", property)
+	}
+
+	return i, nil
+}
+
+// OneOfSchema is schema which value must be valid against exactly one schema
+type OneOfSchema struct {
+	Schemas       []Schema
+	Discriminator *Discriminator
+}
+
+// ExampleValue returns example of the first schema
+func (o OneOfSchema) ExampleValue() interface{} {
+	if len(o.Schemas) == 0 {
+		return nil
+	}
+
+	return withDiscriminator(o.Schemas[0].ExampleValue(), o.Discriminator)
+}
+
+// Validate -.
+func (o OneOfSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	if o.Discriminator != nil {
+		i, errs := o.Discriminator.schema(pointer, value)
+		if errs != nil {
+			return errs
+		}
+
+		if i >= 0 {
+			return o.Schemas[i].Validate(pointer, value)
+		}
+	}
+
+	matched := 0
+
+	for _, s := range o.Schemas {
+		if len(s.Validate(pointer, value)) == 0 {
+			matched++
+		}
+	}
+
+	if matched != 1 {
+		return violation(pointer, "must match exactly one schema of oneOf, matched %d", matched)
+	}
+
+	return nil
+}
+
+// AnyOfSchema is schema which value must be valid against at least one schema
+type AnyOfSchema struct {
+	Schemas       []Schema
+	Discriminator *Discriminator
+}
+
+// ExampleValue returns example of the first schema
+func (a AnyOfSchema) ExampleValue() interface{} {
+	if len(a.Schemas) == 0 {
+		return nil
+	}
+
+	return withDiscriminator(a.Schemas[0].ExampleValue(), a.Discriminator)
+}
+
+// Validate -.
+func (a AnyOfSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	if a.Discriminator != nil {
+		i, errs := a.Discriminator.schema(pointer, value)
+		if errs != nil {
+			return errs
+		}
+
+		if i >= 0 {
+			return a.Schemas[i].Validate(pointer, value)
+		}
+	}
+
+	for _, s := range a.Schemas {
+		if len(s.Validate(pointer, value)) == 0 {
+			return nil
+		}
+	}
+
+	return violation(pointer, "must match at least one schema of anyOf")
+}
+
+// withDiscriminator returns example of the first schema with discriminator property set to value mapped to schema
+func withDiscriminator(example interface{}, d *Discriminator) interface{} {
+	if nil == d {
+		return example
+	}
+
+	obj, ok := example.(map[string]interface{})
+	if !ok {
+		return example
+	}
+
+	value, ok := d.value(0)
+	if !ok {
+		return example
+	}
+
+	res := make(map[string]interface{}, len(obj)+1)
+
+	for k, v := range obj {
+		res[k] = v
+	}
+
+	res[d.PropertyName] = value
+
+	return res
+}
@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+func TestAllOfSchema(t *testing.T) {
+	s := api.AllOfSchema{
+		Schemas: []api.Schema{
+			api.ObjectSchema{Example: map[string]interface{}{"name": "Rex"}},
+			api.ObjectSchema{Example: map[string]interface{}{"age": int64(3)}},
+			api.StringSchema{},
+		},
+	}
+
+	require.Equal(t, map[string]interface{}{"name": "Rex", "age": int64(3)}, s.ExampleValue())
+	require.Equal(t, api.ValidationErrors{
+		{Pointer: "", Message: "must be string, got object"},
+	}, s.Validate("", map[string]interface{}{}))
+}
+
+func TestOneOfSchema(t *testing.T) {
+	cat := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"petType": api.StringSchema{},
+			"meow":    api.BooleanSchema{},
+		},
+		Required: []string{"meow"},
+	}
+	dog := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"petType": api.StringSchema{},
+			"bark":    api.BooleanSchema{},
+		},
+		Required: []string{"bark"},
+	}
+
+	tests := []struct {
+		name   string
+		schema api.Schema
+		value  interface{}
+		want   api.ValidationErrors
+	}{
+		{
+			name:   "one match",
+			schema: api.OneOfSchema{Schemas: []api.Schema{api.StringSchema{}, api.IntSchema{}}},
+			value:  "a",
+			want:   nil,
+		},
+		{
+			name:   "no match",
+			schema: api.OneOfSchema{Schemas: []api.Schema{api.StringSchema{}, api.IntSchema{}}},
+			value:  true,
+			want:   api.ValidationErrors{{Pointer: "", Message: "must match exactly one schema of oneOf, matched 0"}},
+		},
+		{
+			name:   "many matches",
+			schema: api.OneOfSchema{Schemas: []api.Schema{api.FloatSchema{}, api.IntSchema{}}},
+			value:  float64(1),
+			want:   api.ValidationErrors{{Pointer: "", Message: "must match exactly one schema of oneOf, matched 2"}},
+		},
+		{
+			name: "discriminator",
+			schema: api.OneOfSchema{
+				Schemas:       []api.Schema{cat, dog},
+				Discriminator: &api.Discriminator{PropertyName: "petType", Mapping: map[string]int{"cat": 0, "dog": 1}},
+			},
+			value: map[string]interface{}{"petType": "dog", "meow": true},
+			want:  api.ValidationErrors{{Pointer: "/bark", Message: "required property is missing"}},
+		},
+		{
+			name: "unknown discriminator value",
+			schema: api.OneOfSchema{
+				Schemas:       []api.Schema{cat, dog},
+				Discriminator: &api.Discriminator{PropertyName: "petType", Mapping: map[string]int{"cat": 0, "dog": 1}},
+			},
+			value: map[string]interface{}{"petType": "bird"},
+			want:  api.ValidationErrors{{Pointer: "/petType", Message: "unknown discriminator value bird"}},
+		},
+		{
+			name:   "any of",
+			schema: api.AnyOfSchema{Schemas: []api.Schema{api.FloatSchema{}, api.IntSchema{}}},
+			value:  float64(1),
+			want:   nil,
+		},
+		{
+			name:   "any of no match",
+			schema: api.AnyOfSchema{Schemas: []api.Schema{api.FloatSchema{}, api.IntSchema{}}},
+			value:  "1",
+			want:   api.ValidationErrors{{Pointer: "", Message: "must match at least one schema of anyOf"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.schema.Validate("", tc.value))
+		})
+	}
+}
+
+func TestOneOfSchema_ExampleValue(t *testing.T) {
+	s := api.OneOfSchema{
+		Schemas: []api.Schema{
+			api.ObjectSchema{Example: map[string]interface{}{"meow": true}},
+			api.ObjectSchema{Example: map[string]interface{}{"bark": true}},
+		},
+		Discriminator: &api.Discriminator{PropertyName: "petType", Mapping: map[string]int{"Cat": 0, "Dog": 1}},
+	}
+
+	require.Equal(t, map[string]interface{}{"meow": true, "petType": "Cat"}, s.ExampleValue())
+}
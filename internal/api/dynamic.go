@@ -0,0 +1,207 @@
+package api
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/neotoolkit/faker"
+)
+
+// DynamicValue -.
+func (b BooleanSchema) DynamicValue(f faker.Faker) interface{} {
+	if b.Dynamic {
+		return b.generate(f)
+	}
+
+	return b.Example
+}
+
+// DynamicValue -.
+func (i IntSchema) DynamicValue(f faker.Faker) interface{} {
+	if i.Dynamic {
+		return i.generate(f)
+	}
+
+	return i.Example
+}
+
+// DynamicValue -.
+func (n FloatSchema) DynamicValue(f faker.Faker) interface{} {
+	if n.Dynamic {
+		return n.generate(f)
+	}
+
+	return n.Example
+}
+
+// DynamicValue -.
+func (s StringSchema) DynamicValue(f faker.Faker) interface{} {
+	if s.Dynamic {
+		return s.generate(f)
+	}
+
+	return s.Example
+}
+
+// DynamicValue returns example of array or generates items which respect minItems and maxItems
+func (a ArraySchema) DynamicValue(f faker.Faker) interface{} {
+	if len(a.Example) > 0 && !a.Dynamic {
+		return a.Example
+	}
+
+	count := uint64(1)
+	if a.Dynamic {
+		count = itemsCount(a.MinItems, a.MaxItems)
+	}
+
+	items := make([]interface{}, count)
+
+	for i := range items {
+		items[i] = a.Type.DynamicValue(f)
+	}
+
+	return items
+}
+
+// DynamicValue -.
+func (o ObjectSchema) DynamicValue(f faker.Faker) interface{} {
+	if len(o.Example) > 0 {
+		return o.Example
+	}
+
+	keys := make([]string, 0, len(o.Properties))
+	for key := range o.Properties {
+		keys = append(keys, key)
+	}
+
+	// properties are generated in the same order, so values are reproducible with seed
+	sort.Strings(keys)
+
+	example := make(map[string]interface{}, len(o.Properties))
+
+	for _, key := range keys {
+		example[key] = o.Properties[key].DynamicValue(f)
+	}
+
+	return example
+}
+
+// DynamicValue returns value of faker function
+func (s FakerSchema) DynamicValue(f faker.Faker) interface{} {
+	switch strings.ToLower(s.Name) {
+	case "":
+		return s.Example
+	case "uuid":
+		// faker generates UUID without its generator, so value would not be reproducible with seed
+		return randomUUID(f)
+	}
+
+	return f.ByName(s.Name)
+}
+
+// DynamicValue -.
+func (AnySchema) DynamicValue(faker.Faker) interface{} {
+	return nil
+}
+
+// DynamicValue -.
+func (FalseSchema) DynamicValue(faker.Faker) interface{} {
+	return nil
+}
+
+// DynamicValue -.
+func (n NullableSchema) DynamicValue(f faker.Faker) interface{} {
+	return n.Schema.DynamicValue(f)
+}
+
+// DynamicValue -.
+func (a AllOfSchema) DynamicValue(f faker.Faker) interface{} {
+	values := make([]interface{}, len(a.Schemas))
+
+	for i, s := range a.Schemas {
+		values[i] = s.DynamicValue(f)
+	}
+
+	return mergeExamples(values)
+}
+
+// DynamicValue -.
+func (o OneOfSchema) DynamicValue(f faker.Faker) interface{} {
+	if len(o.Schemas) == 0 {
+		return nil
+	}
+
+	return withDiscriminator(o.Schemas[0].DynamicValue(f), o.Discriminator)
+}
+
+// DynamicValue -.
+func (a AnyOfSchema) DynamicValue(f faker.Faker) interface{} {
+	if len(a.Schemas) == 0 {
+		return nil
+	}
+
+	return withDiscriminator(a.Schemas[0].DynamicValue(f), a.Discriminator)
+}
+
+// Generate returns value generated by faker for schema, examples of schema are ignored
+func Generate(s Schema, f faker.Faker) interface{} {
+	switch schema := s.(type) {
+	case BooleanSchema:
+		return schema.generate(f)
+	case IntSchema:
+		return schema.generate(f)
+	case FloatSchema:
+		return schema.generate(f)
+	case StringSchema:
+		return schema.generate(f)
+	case ArraySchema:
+		items := make([]interface{}, itemsCount(schema.MinItems, schema.MaxItems))
+
+		for i := range items {
+			items[i] = Generate(schema.Type, f)
+		}
+
+		return items
+	case ObjectSchema:
+		keys := make([]string, 0, len(schema.Properties))
+		for key := range schema.Properties {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		value := make(map[string]interface{}, len(keys))
+
+		for _, key := range keys {
+			value[key] = Generate(schema.Properties[key], f)
+		}
+
+		return value
+	case NullableSchema:
+		return Generate(schema.Schema, f)
+	case AllOfSchema:
+		values := make([]interface{}, len(schema.Schemas))
+
+		for i, sub := range schema.Schemas {
+			values[i] = Generate(sub, f)
+		}
+
+		return mergeExamples(values)
+	case OneOfSchema:
+		if len(schema.Schemas) == 0 {
+			return nil
+		}
+
+		return withDiscriminator(Generate(schema.Schemas[0], f), schema.Discriminator)
+	case AnyOfSchema:
+		if len(schema.Schemas) == 0 {
+			return nil
+		}
+
+		return withDiscriminator(Generate(schema.Schemas[0], f), schema.Discriminator)
+	case nil:
+		return nil
+	}
+
+	return s.DynamicValue(f)
+}
@@ -0,0 +1,70 @@
+package api_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/neotoolkit/faker"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func seededFaker(seed int64) faker.Faker {
+	f := faker.NewFaker()
+	f.Generator = rand.New(rand.NewSource(seed))
+
+	return f
+}
+
+func TestResponse_DynamicValue(t *testing.T) {
+	b := api.Builder{Faker: seededFaker(1)}
+
+	operation, err := b.Set("/", "GET", &openapi.Operation{
+		Responses: openapi.Responses{
+			"200": {
+				Content: openapi.Content{
+					"application/json": {
+						Schema: openapi.Schema{
+							Type: "object",
+							Properties: openapi.Schemas{
+								"id":    {Type: "string", Format: "uuid"},
+								"name":  {Faker: "person.name"},
+								"token": {Faker: "uuid"},
+								"tags":  {Type: "array", Items: &openapi.Schema{Type: "string"}, MinItems: uint64Ptr(2), MaxItems: uint64Ptr(2)},
+								"kind":  {Type: "string", Example: "user"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	response := operation.Responses[0]
+
+	first := response.DynamicValue("", seededFaker(42))
+	second := response.DynamicValue("", seededFaker(42))
+	other := response.DynamicValue("", seededFaker(43))
+
+	require.Equal(t, first, second)
+	require.NotEqual(t, first, other)
+	require.Empty(t, response.Schema.Validate("", first))
+
+	value, ok := first.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "user", value["kind"])
+	require.Len(t, value["tags"], 2)
+	require.NotEmpty(t, value["name"])
+}
+
+func TestResponse_DynamicValue_Example(t *testing.T) {
+	response := api.Response{
+		Schema:  api.StringSchema{Dynamic: true},
+		Example: "id",
+	}
+
+	require.Equal(t, "id", response.DynamicValue("", seededFaker(1)))
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExamplePolicy is policy for response examples which do not match their schemas
+type ExamplePolicy string
+
+const (
+	// ExamplePolicyWarn reports example and serves it
+	ExamplePolicyWarn ExamplePolicy = "warn"
+	// ExamplePolicyFail returns error from Build
+	ExamplePolicyFail ExamplePolicy = "fail"
+	// ExamplePolicyFallback reports example and serves value of schema instead
+	ExamplePolicyFallback ExamplePolicy = "fallback"
+)
+
+// ExampleError is error of response example which does not match schema
+type ExampleError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Key is name of example in examples, empty key is example
+	Key string
+	// Header is name of response header, empty header is example of response body
+	Header string
+	// Schema is true for example of schema, e.g. example of property
+	Schema bool
+	Errs   ValidationErrors
+}
+
+// Error -.
+func (e *ExampleError) Error() string {
+	example := "example"
+	if e.Key != "" {
+		example = "example " + e.Key
+	}
+
+	if e.Schema {
+		example = "schema example"
+	}
+
+	if e.Header != "" {
+		example += " of header " + e.Header
+	}
+
+	return fmt.Sprintf("%s of %s %s response %d does not match schema: %v", example, e.Method, e.Path, e.StatusCode, e.Errs)
+}
+
+// Unwrap -.
+func (e *ExampleError) Unwrap() error {
+	return e.Errs
+}
+
+// ParseExamplePolicy returns policy by name, empty name is ExamplePolicyWarn
+func ParseExamplePolicy(name string) (ExamplePolicy, error) {
+	switch p := ExamplePolicy(strings.ToLower(name)); p {
+	case "":
+		return ExamplePolicyWarn, nil
+	case ExamplePolicyWarn, ExamplePolicyFail, ExamplePolicyFallback:
+		return p, nil
+	}
+
+	return "", fmt.Errorf("unknown example policy %s, must be one of warn, fail, fallback", name)
+}
+
+// checkExample returns false if example must not be served according to policy of builder
+func (b *Builder) checkExample(e *ExampleError, schema Schema, example interface{}) (bool, error) {
+	e.Errs = schema.Validate("", example)
+	if len(e.Errs) == 0 {
+		return true, nil
+	}
+
+	if b.ExamplePolicy == ExamplePolicyFail {
+		return false, e
+	}
+
+	if b.Warn != nil {
+		b.Warn(e)
+	}
+
+	return b.ExamplePolicy != ExamplePolicyFallback, nil
+}
+
+// at returns copy of builder which converts schemas of response, examples of the schemas are checked by policy
+func (b *Builder) at(response *ExampleError) *Builder {
+	res := *b
+	res.response = response
+
+	return &res
+}
+
+// schemaExample returns example of schema which is served according to policy, rejected example is nil
+// examples of schemas which are not converted for response are not checked
+func (b *Builder) schemaExample(schema Schema, example interface{}) (interface{}, error) {
+	if nil == b.response || nil == example {
+		return example, nil
+	}
+
+	e := *b.response
+	e.Schema = true
+
+	ok, err := b.checkExample(&e, schema, example)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return example, nil
+}
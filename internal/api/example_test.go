@@ -0,0 +1,232 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+const exampleSpec = `
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                required:
+                  - id
+                properties:
+                  id:
+                    type: integer
+                    example: 1
+              example:
+                id: one
+              examples:
+                first:
+                  value:
+                    name: John
+                second:
+                  value:
+                    id: 2
+`
+
+func TestExampleError(t *testing.T) {
+	err := &api.ExampleError{
+		Method:     "GET",
+		Path:       "/users",
+		StatusCode: 200,
+		Key:        "first",
+		Errs:       api.ValidationErrors{{Pointer: "/id", Message: "required property is missing"}},
+	}
+
+	require.EqualError(t, err, "example first of GET /users response 200 does not match schema: /id: required property is missing")
+	require.True(t, errors.As(err, new(api.ValidationErrors)))
+}
+
+func TestParseExamplePolicy(t *testing.T) {
+	p, err := api.ParseExamplePolicy("")
+
+	require.NoError(t, err)
+	require.Equal(t, api.ExamplePolicyWarn, p)
+
+	p, err = api.ParseExamplePolicy("Fallback")
+
+	require.NoError(t, err)
+	require.Equal(t, api.ExamplePolicyFallback, p)
+
+	_, err = api.ParseExamplePolicy("ignore")
+
+	require.EqualError(t, err, "unknown example policy ignore, must be one of warn, fail, fallback")
+}
+
+func TestBuilder_ExamplePolicy(t *testing.T) {
+	var oapi openapi.OpenAPI
+
+	require.NoError(t, yaml.Unmarshal([]byte(exampleSpec), &oapi))
+
+	tests := []struct {
+		name     string
+		policy   api.ExamplePolicy
+		example  interface{}
+		examples map[string]interface{}
+		warnings int
+		// value is served for unknown example key
+		value interface{}
+		err   string
+	}{
+		{
+			name:    "warn",
+			policy:  api.ExamplePolicyWarn,
+			example: map[string]interface{}{"id": "one"},
+			examples: map[string]interface{}{
+				"":       map[string]interface{}{"name": "John"},
+				"first":  map[string]interface{}{"name": "John"},
+				"second": map[string]interface{}{"id": uint64(2)},
+			},
+			warnings: 2,
+			value:    map[string]interface{}{"id": "one"},
+		},
+		{
+			// first example is rejected, so the second one is served by default
+			name:    "fallback",
+			policy:  api.ExamplePolicyFallback,
+			example: nil,
+			examples: map[string]interface{}{
+				"":       map[string]interface{}{"id": uint64(2)},
+				"second": map[string]interface{}{"id": uint64(2)},
+			},
+			warnings: 2,
+			value:    map[string]interface{}{"id": int64(1)},
+		},
+		{
+			name:   "fail",
+			policy: api.ExamplePolicyFail,
+			err:    "example of GET /users response 200 does not match schema: /id: must be integer, got string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var warnings []error
+
+			b := &api.Builder{
+				OpenAPI:       oapi,
+				ExamplePolicy: tc.policy,
+				Warn: func(err error) {
+					warnings = append(warnings, err)
+				},
+			}
+
+			a, err := b.Build()
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, warnings, tc.warnings)
+
+			resp := a.Operations[0].Responses[0]
+
+			require.Equal(t, tc.example, resp.Example)
+			require.Equal(t, tc.examples, resp.Examples)
+			require.Equal(t, tc.value, resp.ExampleValue("missing"))
+		})
+	}
+}
+
+const schemaExampleSpec = `
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+                example: many
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: integer
+                    example: one
+                  name:
+                    type: string
+                    example: John
+`
+
+func TestBuilder_SchemaExamplePolicy(t *testing.T) {
+	var oapi openapi.OpenAPI
+
+	require.NoError(t, yaml.Unmarshal([]byte(schemaExampleSpec), &oapi))
+
+	tests := []struct {
+		name     string
+		policy   api.ExamplePolicy
+		warnings []string
+		value    interface{}
+		err      string
+	}{
+		{
+			name:   "warn",
+			policy: api.ExamplePolicyWarn,
+			warnings: []string{
+				"schema example of header X-Rate-Limit of GET /users response 200 does not match schema: /: must be integer, got string",
+				"schema example of GET /users response 200 does not match schema: /: must be integer, got string",
+			},
+			value: map[string]interface{}{"id": int64(0), "name": "John"},
+		},
+		{
+			name:   "fallback",
+			policy: api.ExamplePolicyFallback,
+			warnings: []string{
+				"schema example of header X-Rate-Limit of GET /users response 200 does not match schema: /: must be integer, got string",
+				"schema example of GET /users response 200 does not match schema: /: must be integer, got string",
+			},
+			value: map[string]interface{}{"id": int64(0), "name": "John"},
+		},
+		{
+			name:   "fail",
+			policy: api.ExamplePolicyFail,
+			err:    "schema example of header X-Rate-Limit of GET /users response 200 does not match schema: /: must be integer, got string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var warnings []string
+
+			b := &api.Builder{
+				OpenAPI:       oapi,
+				ExamplePolicy: tc.policy,
+				Warn: func(err error) {
+					warnings = append(warnings, err.Error())
+				},
+			}
+
+			a, err := b.Build()
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.warnings, warnings)
+			require.Equal(t, tc.value, a.Operations[0].Responses[0].ExampleValue(""))
+		})
+	}
+}
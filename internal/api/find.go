@@ -1,10 +1,9 @@
 package api
 
 import (
-	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -21,76 +20,119 @@ func (e *FindResponseError) Error() string {
 
 // FindResponseParams -.
 type FindResponseParams struct {
-	Path      string
-	Method    string
-	Body      io.ReadCloser
-	MediaType string
+	Path   string
+	Method string
+	Body   io.ReadCloser
+	// Accept is Accept header of request, empty Accept accepts any media type
+	Accept string
+	Query  url.Values
+	Header http.Header
+	// StatusCode selects response, zero selects default response of operation
+	StatusCode int
 }
 
-// ErrEmptyRequireField -.
-var ErrEmptyRequireField = errors.New("empty require field")
-
 // FindResponse -.
 func (a API) FindResponse(params FindResponseParams) (Response, error) {
-	operation, ok := a.findOperation(params)
-	if !ok {
-		return Response{}, &FindResponseError{
-			Method: params.Method,
-			Path:   params.Path,
-		}
+	route, err := a.Router().Find(params.Path, params.Method)
+	if err != nil {
+		return Response{}, err
 	}
 
-	switch params.Method {
-	case http.MethodPost, http.MethodPut, http.MethodPatch:
-		var body map[string]interface{}
+	operation := route.Operation
+
+	errs := operation.ValidateParameters(params, route.Params)
 
-		err := json.NewDecoder(params.Body).Decode(&body)
+	// body is not read if operation has no request body
+	if operation.RequestBody != nil {
+		bodyErrs, err := operation.validateBody(params)
 		if err != nil {
 			return Response{}, err
 		}
 
-		for k, v := range operation.Body {
-			_, ok := body[k]
-			if !ok && v.Required {
-				return Response{}, ErrEmptyRequireField
-			}
-		}
+		errs = append(errs, bodyErrs...)
 	}
 
-	response, ok := operation.findOperationResponse(params)
-	if !ok {
-		return operation.Responses[0], nil
+	if len(errs) > 0 {
+		return Response{}, errs
 	}
 
-	return response, nil
+	return operation.findOperationResponse(params)
 }
 
-func (a API) findOperation(params FindResponseParams) (Operation, bool) {
-	for _, op := range a.Operations {
-		if !IsPathMatchTemplate(params.Path, op.Path) {
+// FindOperation returns operation for path and method
+func (a API) FindOperation(path, method string) (Operation, bool) {
+	route, err := a.Router().Find(path, method)
+	if err != nil {
+		return Operation{}, false
+	}
+
+	return route.Operation, true
+}
+
+// findOperationResponse returns response with status code of params and media type which is negotiated by Accept
+// Response without body is returned if status code is not documented
+func (o Operation) findOperationResponse(params FindResponseParams) (Response, error) {
+	code := params.StatusCode
+	if code == 0 {
+		code = o.DefaultStatusCode()
+	}
+
+	var (
+		found      []Response
+		mediaTypes []string
+	)
+
+	for _, r := range o.Responses {
+		if r.StatusCode != code {
 			continue
 		}
 
-		if params.Method != op.Method {
-			continue
+		// response without content is acceptable for any client
+		if r.MediaType == "" {
+			return r, nil
 		}
 
-		return op, true
+		found = append(found, r)
+		mediaTypes = append(mediaTypes, r.MediaType)
+	}
+
+	if len(found) == 0 {
+		return Response{StatusCode: code}, nil
+	}
+
+	mediaType, ok := Negotiate(params.Accept, mediaTypes)
+	if !ok {
+		return Response{}, &NotAcceptableError{Accept: params.Accept, MediaTypes: mediaTypes}
+	}
+
+	for _, r := range found {
+		if r.MediaType == mediaType {
+			return r, nil
+		}
 	}
 
-	return Operation{}, false
+	return found[0], nil
 }
 
-func (o Operation) findOperationResponse(params FindResponseParams) (Response, bool) {
+// DefaultStatusCode returns the lowest 2xx status code of operation or the lowest status code if there is no 2xx response
+func (o Operation) DefaultStatusCode() int {
+	code := 0
+
 	for _, r := range o.Responses {
-		if r.MediaType != params.MediaType {
-			continue
+		success := r.StatusCode >= http.StatusOK && r.StatusCode < http.StatusMultipleChoices
+		currentSuccess := code >= http.StatusOK && code < http.StatusMultipleChoices
+
+		switch {
+		case code == 0, success && !currentSuccess, success == currentSuccess && r.StatusCode < code:
+			code = r.StatusCode
 		}
+	}
 
-		return r, true
+	if code == 0 {
+		return http.StatusOK
 	}
 
-	return Response{}, false
+	return code
 }
 
 // IsPathMatchTemplate returns true if path matches template
@@ -3,7 +3,7 @@ package api_test
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,24 +11,18 @@ import (
 	"github.com/neotoolkit/dummy/internal/api"
 )
 
-type ResponseParamsBody struct {
-	Body         interface{}
-	IsBodyBroken bool
-}
-
-func (params ResponseParamsBody) Read(p []byte) (n int, err error) {
-	jsonData, err := json.Marshal(params.Body)
-	if params.IsBodyBroken || err != nil {
-		jsonData = []byte{1, 2, 3}
-	}
+// jsonBody returns request body with JSON of value, broken body is not JSON
+func jsonBody(t *testing.T, value interface{}, broken bool) io.ReadCloser {
+	t.Helper()
 
-	readerResult, readerError := bytes.NewReader(jsonData).Read(p)
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
 
-	return readerResult, readerError
-}
+	if broken {
+		data = []byte{1, 2, 3}
+	}
 
-func (ResponseParamsBody) Close() error {
-	return nil
+	return io.NopCloser(bytes.NewReader(data))
 }
 
 func TestIsPathMatchTemplate(t *testing.T) {
@@ -101,14 +95,18 @@ func TestFindResponseError(t *testing.T) {
 }
 
 func TestFindResponse(t *testing.T) {
-	bodyFixedPath := map[string]api.FieldType{
-		"param1": {
-			Required: true,
-			Type:     "string",
-		},
-		"param2": {
-			Required: false,
-			Type:     "string",
+	bodyFixedPath := &api.RequestBody{
+		Content: []api.RequestContent{
+			{
+				MediaType: "application/json",
+				Schema: api.ObjectSchema{
+					Properties: map[string]api.Schema{
+						"param1": api.StringSchema{},
+						"param2": api.StringSchema{},
+					},
+					Required: []string{"param1"},
+				},
+			},
 		},
 	}
 
@@ -122,9 +120,9 @@ func TestFindResponse(t *testing.T) {
 	}
 
 	operationFixedPath := api.Operation{
-		Method: "POST",
-		Path:   "some/fixed/path",
-		Body:   bodyFixedPath,
+		Method:      "POST",
+		Path:        "some/fixed/path",
+		RequestBody: bodyFixedPath,
 		Responses: []api.Response{
 			responseFixedPathJSON,
 			responseFixedPathZip,
@@ -141,11 +139,12 @@ func TestFindResponse(t *testing.T) {
 		Method: "POST",
 		Path:   "some/other/path",
 	}
-	mismatchByOperationMethodError := api.FindResponseError{
+	mismatchByOperationMethodError := api.MethodNotAllowedError{
 		Method: "GET",
 		Path:   "some/fixed/path",
+		Allow:  []string{"OPTIONS", "POST"},
 	}
-	bodyWithoutRequiredParamError := errors.New("empty require field")
+	bodyWithoutRequiredParamError := api.ValidationErrors{{Pointer: "/param1", Message: "required property is missing"}}
 
 	bodyWithoutRequiredParam := map[string]interface{}{
 		"param3": "qwe",
@@ -163,19 +162,19 @@ func TestFindResponse(t *testing.T) {
 	emptyResponse := api.Response{}
 
 	tests := []struct {
-		name              string
-		path              string
-		method            string
-		body              interface{}
-		wantFirst         api.Response
-		wantSecond        interface{}
-		responseMediaType string
+		name       string
+		path       string
+		method     string
+		body       interface{}
+		wantFirst  api.Response
+		wantSecond interface{}
+		accept     string
 	}{
 		{
 			name:       "Mismatch by operation path",
 			path:       "some/other/path",
 			method:     "POST",
-			body:       bodyFixedPath,
+			body:       bodyWithAllParam,
 			wantFirst:  emptyResponse,
 			wantSecond: &mismatchByOperationPathError,
 		},
@@ -183,7 +182,7 @@ func TestFindResponse(t *testing.T) {
 			name:       "Mismatch by operation method",
 			path:       "some/fixed/path",
 			method:     "GET",
-			body:       bodyFixedPath,
+			body:       bodyWithAllParam,
 			wantFirst:  emptyResponse,
 			wantSecond: &mismatchByOperationMethodError,
 		},
@@ -212,29 +211,35 @@ func TestFindResponse(t *testing.T) {
 			wantSecond: nil,
 		},
 		{
-			name:              "Match by all criteria",
-			path:              "some/fixed/path",
-			method:            "POST",
-			body:              bodyWithAllParam,
-			wantFirst:         responseFixedPathZip,
-			wantSecond:        nil,
-			responseMediaType: "application/zip",
+			name:       "Match by all criteria",
+			path:       "some/fixed/path",
+			method:     "POST",
+			body:       bodyWithAllParam,
+			wantFirst:  responseFixedPathZip,
+			wantSecond: nil,
+			accept:     "application/zip",
+		},
+		{
+			name:      "Not acceptable media type",
+			path:      "some/fixed/path",
+			method:    "POST",
+			body:      bodyWithAllParam,
+			wantFirst: emptyResponse,
+			wantSecond: &api.NotAcceptableError{
+				Accept:     "application/xml",
+				MediaTypes: []string{"application/json", "application/zip"},
+			},
+			accept: "application/xml",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			bodyReader := ResponseParamsBody{Body: tc.body}
-			mediaType := "application/xml"
-
-			if tc.responseMediaType != "" {
-				mediaType = tc.responseMediaType
-			}
 			params := api.FindResponseParams{
-				Path:      tc.path,
-				Method:    tc.method,
-				Body:      bodyReader,
-				MediaType: mediaType,
+				Path:   tc.path,
+				Method: tc.method,
+				Body:   jsonBody(t, tc.body, false),
+				Accept: tc.accept,
 			}
 			firstResult, secondResult := a.FindResponse(params)
 
@@ -245,13 +250,100 @@ func TestFindResponse(t *testing.T) {
 
 	t.Run("Broken json in body reader", func(t *testing.T) {
 		params := api.FindResponseParams{
-			Path:      "some/fixed/path",
-			Method:    "POST",
-			Body:      ResponseParamsBody{Body: bodyWithAllParam, IsBodyBroken: true},
-			MediaType: "application/xml",
+			Path:   "some/fixed/path",
+			Method: "POST",
+			Body:   jsonBody(t, bodyWithAllParam, true),
+			Accept: "application/json",
 		}
 
 		_, err := a.FindResponse(params)
 		require.Error(t, err)
 	})
 }
+
+func TestOperation_DefaultStatusCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []api.Response
+		want      int
+	}{
+		{
+			name: "no responses",
+			want: 200,
+		},
+		{
+			name:      "lowest success",
+			responses: []api.Response{{StatusCode: 400}, {StatusCode: 204}, {StatusCode: 201}},
+			want:      201,
+		},
+		{
+			name:      "lowest without success",
+			responses: []api.Response{{StatusCode: 500}, {StatusCode: 404}},
+			want:      404,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, api.Operation{Responses: tc.responses}.DefaultStatusCode())
+		})
+	}
+}
+
+func TestFindResponse_StatusCode(t *testing.T) {
+	a := api.API{
+		Operations: []api.Operation{
+			{
+				Method: "GET",
+				Path:   "/users/{userId}",
+				Responses: []api.Response{
+					{StatusCode: 200, MediaType: "application/json"},
+					{StatusCode: 404, MediaType: "application/json"},
+					{StatusCode: 404, MediaType: "application/problem+json"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		accept     string
+		want       api.Response
+	}{
+		{
+			name: "default",
+			want: api.Response{StatusCode: 200, MediaType: "application/json"},
+		},
+		{
+			name:       "documented",
+			statusCode: 404,
+			want:       api.Response{StatusCode: 404, MediaType: "application/json"},
+		},
+		{
+			name:       "documented with media type",
+			statusCode: 404,
+			accept:     "application/problem+json",
+			want:       api.Response{StatusCode: 404, MediaType: "application/problem+json"},
+		},
+		{
+			name:       "not documented",
+			statusCode: 500,
+			want:       api.Response{StatusCode: 500},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.FindResponse(api.FindResponseParams{
+				Path:       "/users/1",
+				Method:     "GET",
+				Accept:     tc.accept,
+				StatusCode: tc.statusCode,
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"time"
+
+	"github.com/neotoolkit/faker"
+)
+
+const (
+	// defaultRange is width of range of generated numbers if schema has no minimum or maximum
+	defaultRange = 100
+	// maxRepeat is maximum number of extra repetitions for unbounded quantifiers of pattern
+	maxRepeat = 3
+	// maxExactSpan is maximum count of multiples which are chosen by Int63n, wider ranges are chosen by Float64
+	maxExactSpan = 1 << 62
+)
+
+// maxInt is the greatest float64 which converts to int64 without overflow, float64(math.MaxInt64) is 2^63
+var maxInt = math.Nextafter(math.MaxInt64, 0)
+
+// generate returns random value of boolean
+func (b BooleanSchema) generate(f faker.Faker) bool {
+	return f.Boolean().Boolean()
+}
+
+// generate returns random integer which respects enum, minimum, maximum and multipleOf
+func (i IntSchema) generate(f faker.Faker) int64 {
+	if v, ok := randomEnum(f, i.Enum); ok {
+		n, _ := toFloat64(v)
+
+		return int64(n)
+	}
+
+	lo, hi := numberRange(i.Minimum, i.Maximum)
+
+	lo = math.Ceil(lo)
+	if i.ExclusiveMinimum && i.Minimum != nil && lo == *i.Minimum {
+		lo++
+	}
+
+	hi = math.Floor(hi)
+	if i.ExclusiveMaximum && i.Maximum != nil && hi == *i.Maximum {
+		hi--
+	}
+
+	lo = math.Max(lo, math.MinInt64)
+	hi = math.Min(hi, maxInt)
+
+	step := float64(1)
+	if i.MultipleOf != nil && *i.MultipleOf > 0 {
+		step = *i.MultipleOf
+	}
+
+	return int64(math.Min(randomMultiple(f, lo, hi, step), maxInt))
+}
+
+// generate returns random number which respects enum, minimum, maximum and multipleOf
+func (n FloatSchema) generate(f faker.Faker) float64 {
+	if v, ok := randomEnum(f, n.Enum); ok {
+		res, _ := toFloat64(v)
+
+		return res
+	}
+
+	lo, hi := numberRange(n.Minimum, n.Maximum)
+
+	if n.MultipleOf != nil && *n.MultipleOf > 0 {
+		return randomMultiple(f, lo, hi, *n.MultipleOf)
+	}
+
+	v := lo + f.Generator.Float64()*(hi-lo)
+
+	// random value is rounded to cents to keep it readable
+	v = math.Round(v*100) / 100
+	if v < lo || (n.ExclusiveMinimum && v == lo) || v > hi || (n.ExclusiveMaximum && v == hi) {
+		v = (lo + hi) / 2
+	}
+
+	return v
+}
+
+func numberRange(minimum, maximum *float64) (float64, float64) {
+	switch {
+	case minimum != nil && maximum != nil:
+		return *minimum, *maximum
+	case minimum != nil:
+		return *minimum, *minimum + defaultRange
+	case maximum != nil:
+		return *maximum - defaultRange, *maximum
+	default:
+		return 0, defaultRange
+	}
+}
+
+// randomMultiple returns random multiple of step between lo and hi, lo is returned if there is no such multiple
+func randomMultiple(f faker.Faker, lo, hi, step float64) float64 {
+	first := math.Ceil(lo / step)
+	last := math.Floor(hi / step)
+
+	if last < first {
+		return lo
+	}
+
+	// count of multiples of wide range like full int64 overflows Int63n
+	if last-first >= maxExactSpan {
+		return (first + math.Floor(f.Generator.Float64()*(last-first))) * step
+	}
+
+	return (first + float64(f.Generator.Int63n(int64(last-first)+1))) * step
+}
+
+// itemsCount returns count of generated array items, it is one if minItems and maxItems allow it
+func itemsCount(minItems, maxItems *uint64) uint64 {
+	count := uint64(1)
+
+	if minItems != nil && *minItems > count {
+		count = *minItems
+	}
+
+	if maxItems != nil && *maxItems < count {
+		count = *maxItems
+	}
+
+	return count
+}
+
+// generate returns random string which respects enum, pattern, format and length
+func (s StringSchema) generate(f faker.Faker) string {
+	if v, ok := randomEnum(f, s.Enum); ok {
+		if str, ok := v.(string); ok {
+			return str
+		}
+	}
+
+	if s.Pattern != "" {
+		if str, ok := randomPattern(f, s.Pattern); ok && s.lengthValid(str) {
+			return str
+		}
+	}
+
+	if str, ok := randomFormat(f, s.Format); ok {
+		return str
+	}
+
+	lo, hi := uint64(5), uint64(10)
+
+	if s.MinLength != nil {
+		lo = *s.MinLength
+		if hi < lo {
+			hi = lo + 5
+		}
+	}
+
+	if s.MaxLength != nil {
+		hi = *s.MaxLength
+		if lo > hi {
+			lo = hi
+		}
+	}
+
+	length := f.IntBetween(int(lo), int(hi))
+
+	return f.Asciify(strings.Repeat("*", length))
+}
+
+func (s StringSchema) lengthValid(str string) bool {
+	length := uint64(len([]rune(str)))
+
+	return (s.MinLength == nil || length >= *s.MinLength) && (s.MaxLength == nil || length <= *s.MaxLength)
+}
+
+// randomFormat returns random string of format, it returns false for unknown formats
+func randomFormat(f faker.Faker, format string) (string, bool) {
+	switch format {
+	case "uuid":
+		return randomUUID(f), true
+	case "email":
+		return f.Internet().Email(), true
+	case "date":
+		return randomTime(f).Format("2006-01-02"), true
+	case "date-time":
+		return randomTime(f).Format(time.RFC3339), true
+	case "uri", "url":
+		return "https://" + f.Internet().Domain() + "/" + f.Asciify("*****"), true
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", f.IntBetween(1, 254), f.IntBetween(0, 255), f.IntBetween(0, 255), f.IntBetween(1, 254)), true
+	case "ipv6":
+		groups := make([]string, 8)
+
+		for i := range groups {
+			groups[i] = fmt.Sprintf("%x", f.IntBetween(0, 0xffff))
+		}
+
+		return strings.Join(groups, ":"), true
+	case "hostname":
+		return f.Internet().Domain(), true
+	case "byte":
+		return base64.StdEncoding.EncodeToString([]byte(f.Asciify("********"))), true
+	}
+
+	return "", false
+}
+
+// randomUUID returns random UUID version 4 from generator of faker
+func randomUUID(f faker.Faker) string {
+	var b [16]byte
+
+	for i := range b {
+		b[i] = byte(f.Generator.Intn(256))
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// randomTime returns random time between 2000 and 2030 years
+func randomTime(f faker.Faker) time.Time {
+	from := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	to := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	return time.Unix(from+f.Generator.Int63n(to-from), 0).UTC()
+}
+
+func randomEnum(f faker.Faker, enum []interface{}) (interface{}, bool) {
+	if len(enum) == 0 {
+		return nil, false
+	}
+
+	return enum[f.Generator.Intn(len(enum))], true
+}
+
+// randomPattern returns random string which matches regular expression
+func randomPattern(f faker.Faker, pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	var sb strings.Builder
+
+	writePattern(f, &sb, re.Simplify())
+
+	str := sb.String()
+
+	if matched, err := regexp.MatchString(pattern, str); err != nil || !matched {
+		return "", false
+	}
+
+	return str, true
+}
+
+func writePattern(f faker.Faker, sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		sb.WriteRune(randomRune(f, re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteString(f.Asciify("*"))
+	case syntax.OpCapture:
+		writePattern(f, sb, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writePattern(f, sb, sub)
+		}
+	case syntax.OpAlternate:
+		writePattern(f, sb, re.Sub[f.Generator.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := repeatRange(re)
+
+		for i := f.IntBetween(lo, hi); i > 0; i-- {
+			writePattern(f, sb, re.Sub[0])
+		}
+	}
+}
+
+func repeatRange(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, maxRepeat
+	case syntax.OpPlus:
+		return 1, 1 + maxRepeat
+	case syntax.OpQuest:
+		return 0, 1
+	}
+
+	if re.Max < 0 {
+		return re.Min, re.Min + maxRepeat
+	}
+
+	return re.Min, re.Max
+}
+
+// randomRune returns random rune of character class, printable ASCII characters are preferred
+func randomRune(f faker.Faker, ranges []rune) rune {
+	if len(ranges) == 0 {
+		return 'a'
+	}
+
+	printable := make([]rune, 0, len(ranges))
+
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+
+		if lo < ' ' {
+			lo = ' '
+		}
+
+		if hi > '~' {
+			hi = '~'
+		}
+
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+
+	if len(printable) == 0 {
+		printable = ranges
+	}
+
+	i := f.Generator.Intn(len(printable)/2) * 2
+
+	return printable[i] + rune(f.Generator.Intn(int(printable[i+1]-printable[i])+1))
+}
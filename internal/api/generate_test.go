@@ -0,0 +1,109 @@
+package api_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/neotoolkit/faker"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func TestBuilder_GeneratedExamples(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema openapi.Schema
+	}{
+		{name: "uuid", schema: openapi.Schema{Type: "string", Format: "uuid"}},
+		{name: "email", schema: openapi.Schema{Type: "string", Format: "email"}},
+		{name: "date", schema: openapi.Schema{Type: "string", Format: "date"}},
+		{name: "date-time", schema: openapi.Schema{Type: "string", Format: "date-time"}},
+		{name: "uri", schema: openapi.Schema{Type: "string", Format: "uri"}},
+		{name: "ipv4", schema: openapi.Schema{Type: "string", Format: "ipv4"}},
+		{name: "ipv6", schema: openapi.Schema{Type: "string", Format: "ipv6"}},
+		{name: "hostname", schema: openapi.Schema{Type: "string", Format: "hostname"}},
+		{name: "byte", schema: openapi.Schema{Type: "string", Format: "byte"}},
+		{name: "string enum", schema: openapi.Schema{Type: "string", Enum: []interface{}{"cat", "dog"}}},
+		{name: "string length", schema: openapi.Schema{Type: "string", MinLength: uint64Ptr(12), MaxLength: uint64Ptr(14)}},
+		{name: "short string", schema: openapi.Schema{Type: "string", MaxLength: uint64Ptr(2)}},
+		{name: "pattern", schema: openapi.Schema{Type: "string", Pattern: `^[A-Z]{2}-\d{3,5}(-[a-f]+)?$`}},
+		{name: "integer range", schema: openapi.Schema{Type: "integer", Minimum: float64Ptr(10), Maximum: float64Ptr(12)}},
+		{name: "integer exclusive", schema: openapi.Schema{Type: "integer", Minimum: float64Ptr(1), Maximum: float64Ptr(3), ExclusiveMinimum: true, ExclusiveMaximum: true}},
+		{name: "integer multiple of", schema: openapi.Schema{Type: "integer", Minimum: float64Ptr(1), MultipleOf: float64Ptr(7)}},
+		{name: "full range integer", schema: openapi.Schema{Type: "integer", Minimum: float64Ptr(0), Maximum: float64Ptr(math.MaxInt64)}},
+		{name: "int64 range", schema: openapi.Schema{Type: "integer", Format: "int64", Minimum: float64Ptr(math.MinInt64), Maximum: float64Ptr(math.MaxInt64)}},
+		{name: "integer enum", schema: openapi.Schema{Type: "integer", Enum: []interface{}{uint64(3), uint64(5)}}},
+		{name: "negative number", schema: openapi.Schema{Type: "number", Maximum: float64Ptr(-1)}},
+		{name: "number multiple of", schema: openapi.Schema{Type: "number", Minimum: float64Ptr(0.5), Maximum: float64Ptr(2), MultipleOf: float64Ptr(0.25)}},
+		{
+			name: "array",
+			schema: openapi.Schema{
+				Type:        "array",
+				Items:       &openapi.Schema{Type: "string", Format: "uuid"},
+				MinItems:    uint64Ptr(3),
+				MaxItems:    uint64Ptr(5),
+				UniqueItems: true,
+			},
+		},
+		{
+			name: "object",
+			schema: openapi.Schema{
+				Type: "object",
+				Properties: openapi.Schemas{
+					"id":    {Type: "integer", Minimum: float64Ptr(1)},
+					"email": {Type: "string", Format: "email"},
+				},
+				Required: []string{"id", "email"},
+			},
+		},
+	}
+
+	f := faker.NewFaker()
+	f.Generator = rand.New(rand.NewSource(1))
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := api.Builder{Faker: f}
+
+			for i := 0; i < 50; i++ {
+				operation, err := b.Set("/", "GET", &openapi.Operation{
+					Responses: openapi.Responses{
+						"200": {
+							Content: openapi.Content{
+								"application/json": {Schema: tc.schema},
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				response := operation.Responses[0]
+				example := response.ExampleValue("")
+
+				require.NotEmpty(t, example)
+				require.Empty(t, response.Schema.Validate("", example), "example %v", example)
+			}
+		})
+	}
+}
+
+func TestBuilder_ExplicitExample(t *testing.T) {
+	f := faker.NewFaker()
+	b := api.Builder{Faker: f}
+
+	schema, err := b.Set("/", "GET", &openapi.Operation{
+		Responses: openapi.Responses{
+			"200": {
+				Content: openapi.Content{
+					"application/json": {Schema: openapi.Schema{Type: "string", Format: "uuid", Example: "id"}},
+				},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "id", schema.Responses[0].ExampleValue(""))
+}
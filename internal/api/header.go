@@ -0,0 +1,156 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+// Header is response header
+type Header struct {
+	Name     string
+	Required bool
+	Schema   Schema
+	Example  interface{}
+}
+
+// ExampleValue returns example of header or example of its schema
+func (h Header) ExampleValue() string {
+	if h.Example != nil {
+		return HeaderValue(h.Example)
+	}
+
+	return HeaderValue(h.Schema.ExampleValue())
+}
+
+// DynamicValue returns example of header, header without example is generated by faker
+func (h Header) DynamicValue(f faker.Faker) string {
+	if h.Example != nil {
+		return HeaderValue(h.Example)
+	}
+
+	return HeaderValue(h.Schema.DynamicValue(f))
+}
+
+// GeneratedValue returns value of header which is generated by faker even if there is example
+func (h Header) GeneratedValue(f faker.Faker) string {
+	return HeaderValue(Generate(h.Schema, f))
+}
+
+// HeaderValue returns value serialized with simple style: items of array and keys with values of object
+// are separated by comma, e.g. a,b,c
+func HeaderValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = HeaderValue(item)
+		}
+
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, 2*len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, key, HeaderValue(v[key]))
+		}
+
+		return strings.Join(pairs, ",")
+	}
+
+	return fmt.Sprint(value)
+}
+
+// convertHeaders returns headers of response ordered by name, Content-Type is ignored as OpenAPI requires
+func (b *Builder) convertHeaders(method, path string, statusCode int, resp *openapi.Response) ([]Header, error) {
+	if nil == resp || len(resp.Headers) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		if resp.Headers[name] != nil && !strings.EqualFold(name, "Content-Type") {
+			names = append(names, name)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return http.CanonicalHeaderKey(names[i]) < http.CanonicalHeaderKey(names[j])
+	})
+
+	headers := make([]Header, 0, len(names))
+
+	for _, name := range names {
+		h := *resp.Headers[name]
+
+		if h.Ref != "" {
+			header, err := b.OpenAPI.LookupHeader(h.Ref)
+			if err != nil {
+				return nil, fmt.Errorf("resolve reference: %w", err)
+			}
+
+			h = header
+		}
+
+		header := Header{
+			Name:     http.CanonicalHeaderKey(name),
+			Required: h.Required,
+			Schema:   AnySchema{},
+		}
+
+		if h.Schema != nil {
+			schema, err := b.at(&ExampleError{Method: method, Path: path, StatusCode: statusCode, Header: header.Name}).convertSubschema(*h.Schema)
+			if err != nil {
+				return nil, err
+			}
+
+			header.Schema = schema
+		}
+
+		example, key := h.Example, ""
+		if nil == example && len(h.Examples) > 0 {
+			key = h.Examples.GetKeys()[0]
+			example = h.Examples[key].Value
+		}
+
+		if example != nil {
+			ok, err := b.checkExample(&ExampleError{Method: method, Path: path, StatusCode: statusCode, Key: key, Header: header.Name}, header.Schema, example)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				header.Example = example
+			}
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers, nil
+}
+
+// Validate returns violations of header value which is serialized with simple style
+func (h Header) Validate(value string) ValidationErrors {
+	p := Parameter{Name: h.Name, In: InHeader, Schema: h.Schema}
+
+	return h.Schema.Validate("", Coerce(h.Schema, p.decodeDelimited(value, ",")))
+}
@@ -0,0 +1,120 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/neotoolkit/faker"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func TestHeaderValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "nil", value: nil, want: ""},
+		{name: "string", value: "abc", want: "abc"},
+		{name: "number", value: 1.5, want: "1.5"},
+		{name: "integer", value: uint64(10), want: "10"},
+		{name: "boolean", value: true, want: "true"},
+		{name: "array", value: []interface{}{"a", 1.0}, want: "a,1"},
+		{name: "object", value: map[string]interface{}{"role": "admin", "id": 5.0}, want: "id,5,role,admin"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, api.HeaderValue(tc.value))
+		})
+	}
+}
+
+func TestHeader_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header api.Header
+		value  string
+		want   api.ValidationErrors
+	}{
+		{
+			name:   "integer",
+			header: api.Header{Name: "X-Rate-Limit-Remaining", Schema: api.IntSchema{}},
+			value:  "10",
+		},
+		{
+			name:   "not integer",
+			header: api.Header{Name: "X-Rate-Limit-Remaining", Schema: api.IntSchema{}},
+			value:  "many",
+			want:   api.ValidationErrors{{Message: "must be integer, got string"}},
+		},
+		{
+			name:   "array",
+			header: api.Header{Name: "X-Ids", Schema: api.ArraySchema{Type: api.IntSchema{}}},
+			value:  "1,a",
+			want:   api.ValidationErrors{{Pointer: "/1", Message: "must be integer, got string"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.header.Validate(tc.value))
+		})
+	}
+}
+
+func TestBuilder_Headers(t *testing.T) {
+	b := api.Builder{
+		OpenAPI: openapi.OpenAPI{
+			Components: openapi.Components{
+				Headers: map[string]*openapi.Header{
+					"RateLimit": {Schema: &openapi.Schema{Type: "integer"}, Example: uint64(99)},
+				},
+			},
+		},
+		Faker: faker.NewFaker(),
+	}
+
+	operation, err := b.Set("/users", "POST", &openapi.Operation{
+		Responses: openapi.Responses{
+			"201": {
+				Headers: map[string]*openapi.Header{
+					"location":               {Required: true, Schema: &openapi.Schema{Type: "string", Format: "uri"}, Example: "/users/1"},
+					"X-Rate-Limit-Remaining": {Ref: "#/components/headers/RateLimit"},
+					"ETag":                   {},
+					"Content-Type":           {Schema: &openapi.Schema{Type: "string"}},
+				},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+
+	headers := operation.Responses[0].Headers
+	names := make([]string, len(headers))
+
+	for i, h := range headers {
+		names[i] = h.Name
+	}
+
+	require.Equal(t, []string{"Etag", "Location", "X-Rate-Limit-Remaining"}, names)
+	require.Equal(t, api.AnySchema{}, headers[0].Schema)
+	require.True(t, headers[1].Required)
+	require.Equal(t, "/users/1", headers[1].ExampleValue())
+	require.Equal(t, "99", headers[2].ExampleValue())
+	require.Empty(t, headers[2].Validate(headers[2].GeneratedValue(b.Faker)))
+
+	_, err = b.Set("/users", "GET", &openapi.Operation{
+		Responses: openapi.Responses{
+			"200": {
+				Headers: map[string]*openapi.Header{
+					"X-Rate-Limit-Remaining": {Ref: "#/components/headers/Missing"},
+				},
+			},
+		},
+	})
+
+	require.EqualError(t, err, "resolve reference: unknown header #/components/headers/Missing")
+}
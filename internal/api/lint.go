@@ -0,0 +1,320 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+// Problem is problem of specification, Path is JSON pointer to invalid value in specification
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String -.
+func (p Problem) String() string {
+	return "#" + p.Path + ": " + p.Message
+}
+
+// linter collects problems of specification instead of stopping at the first one like Builder
+type linter struct {
+	oapi openapi.OpenAPI
+	// validator converts schemas to validate examples, so examples are not generated
+	validator *Builder
+	problems  []Problem
+}
+
+// Lint returns problems which prevent building of API and examples which do not match their schemas
+func Lint(oapi openapi.OpenAPI) []Problem {
+	l := &linter{
+		oapi:      oapi,
+		validator: &Builder{OpenAPI: oapi},
+	}
+
+	for i, server := range oapi.Servers {
+		if server != nil {
+			l.server("/servers/"+strconv.Itoa(i), *server)
+		}
+	}
+
+	for _, path := range sortedKeys(oapi.Paths) {
+		item := oapi.Paths[path]
+		if nil == item {
+			continue
+		}
+
+		pointer := JSONPointer("/paths", path)
+
+		l.parameters(pointer+"/parameters", item.Parameters)
+
+		operations := []struct {
+			method    string
+			operation *openapi.Operation
+		}{
+			{method: "get", operation: item.Get},
+			{method: "post", operation: item.Post},
+			{method: "put", operation: item.Put},
+			{method: "patch", operation: item.Patch},
+			{method: "delete", operation: item.Delete},
+			{method: "head", operation: item.Head},
+			{method: "options", operation: item.Options},
+			{method: "trace", operation: item.Trace},
+		}
+
+		for _, o := range operations {
+			if o.operation != nil {
+				l.operation(JSONPointer(pointer, o.method), o.operation)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(oapi.Components.Schemas) {
+		if s := oapi.Components.Schemas[name]; s != nil {
+			l.schema(JSONPointer("/components/schemas", name), *s)
+		}
+	}
+
+	for _, name := range sortedKeys(oapi.Components.Parameters) {
+		if p := oapi.Components.Parameters[name]; p != nil {
+			l.parameter(JSONPointer("/components/parameters", name), *p)
+		}
+	}
+
+	for _, name := range sortedKeys(oapi.Components.Headers) {
+		if h := oapi.Components.Headers[name]; h != nil {
+			l.header(JSONPointer("/components/headers", name), *h)
+		}
+	}
+
+	return l.problems
+}
+
+func (l *linter) add(pointer, format string, a ...interface{}) {
+	l.problems = append(l.problems, Problem{
+		Path:    pointer,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (l *linter) parameters(pointer string, params openapi.Parameters) {
+	for i, p := range params {
+		l.parameter(JSONPointer(pointer, strconv.Itoa(i)), p)
+	}
+}
+
+func (l *linter) parameter(pointer string, p openapi.Parameter) {
+	if p.Ref != "" {
+		if _, err := l.oapi.LookupParameter(p.Ref); err != nil {
+			l.add(JSONPointer(pointer, "$ref"), "unresolvable reference %s", p.Ref)
+		}
+
+		return
+	}
+
+	if p.Schema != nil {
+		l.schema(pointer+"/schema", *p.Schema)
+	}
+}
+
+func (l *linter) operation(pointer string, o *openapi.Operation) {
+	l.parameters(pointer+"/parameters", o.Parameters)
+	l.content(pointer+"/requestBody/content", o.RequestBody.Content, false)
+
+	for _, code := range sortedKeys(o.Responses) {
+		p := JSONPointer(pointer+"/responses", code)
+
+		if _, err := strconv.Atoi(code); err != nil {
+			l.add(p, "status code must be number, got %s", code)
+		}
+
+		if resp := o.Responses[code]; resp != nil {
+			l.headers(p+"/headers", resp.Headers)
+			l.content(p+"/content", resp.Content, true)
+		}
+	}
+}
+
+// server checks that variables of server URL are defined and their defaults are in enum
+func (l *linter) server(pointer string, s openapi.Server) {
+	if _, err := s.Expand(); err != nil {
+		var e *openapi.ServerVariableError
+		if errors.As(err, &e) {
+			l.add(pointer+"/url", "undefined variable %s", e.Name)
+		}
+	}
+
+	for _, name := range sortedKeys(s.Variables) {
+		v := s.Variables[name]
+		if nil == v || len(v.Enum) == 0 {
+			continue
+		}
+
+		found := false
+
+		for _, value := range v.Enum {
+			if value == v.Default {
+				found = true
+			}
+		}
+
+		if !found {
+			l.add(JSONPointer(pointer+"/variables", name)+"/default", "must be one of %s, got %s", strings.Join(v.Enum, ", "), v.Default)
+		}
+	}
+}
+
+func (l *linter) headers(pointer string, headers map[string]*openapi.Header) {
+	for _, name := range sortedKeys(headers) {
+		if h := headers[name]; h != nil {
+			l.header(JSONPointer(pointer, name), *h)
+		}
+	}
+}
+
+// header checks reference, schema and examples of response header
+func (l *linter) header(pointer string, h openapi.Header) {
+	if h.Ref != "" {
+		if _, err := l.oapi.LookupHeader(h.Ref); err != nil {
+			l.add(JSONPointer(pointer, "$ref"), "unresolvable reference %s", h.Ref)
+		}
+
+		return
+	}
+
+	if nil == h.Schema {
+		return
+	}
+
+	l.schema(pointer+"/schema", *h.Schema)
+
+	if h.Example != nil {
+		l.example(pointer+"/example", *h.Schema, h.Example)
+	}
+
+	for _, key := range h.Examples.GetKeys() {
+		l.example(JSONPointer(pointer+"/examples", key)+"/value", *h.Schema, h.Examples[key].Value)
+	}
+}
+
+// content checks schemas and examples of media types, typed is true if schema of JSON must not be empty like in responses
+func (l *linter) content(pointer string, content openapi.Content, typed bool) {
+	for _, mediaType := range sortedKeys(content) {
+		m := content[mediaType]
+		if nil == m {
+			continue
+		}
+
+		p := JSONPointer(pointer, mediaType)
+		s := m.Schema
+
+		if typed && IsJSONMediaType(mediaType) && isEmptySchema(s) {
+			l.add(p+"/schema", "schema must have type")
+
+			continue
+		}
+
+		l.schema(p+"/schema", s)
+
+		if m.Example != nil {
+			l.example(p+"/example", s, m.Example)
+		}
+
+		for _, key := range m.Examples.GetKeys() {
+			l.example(JSONPointer(p+"/examples", key)+"/value", s, m.Examples[key].Value)
+		}
+	}
+}
+
+func (l *linter) schema(pointer string, s openapi.Schema) {
+	if s.Ref != "" {
+		// referenced schema is checked as component, so cyclic references are not followed
+		if _, err := l.oapi.LookupByReference(s.Ref); err != nil {
+			l.add(JSONPointer(pointer, "$ref"), "unresolvable reference %s", s.Ref)
+		}
+
+		return
+	}
+
+	if s.Faker != "" {
+		return
+	}
+
+	switch t := schemaType(s); t {
+	case "", "boolean", "integer", "number", "string", "object":
+	case "array":
+		if nil == s.Items {
+			l.add(pointer, "array must have items")
+		}
+	default:
+		l.add(pointer+"/type", "unknown type %s", t)
+	}
+
+	for _, name := range sortedKeys(s.Properties) {
+		if prop := s.Properties[name]; prop != nil {
+			l.schema(JSONPointer(pointer+"/properties", name), *prop)
+		}
+	}
+
+	if s.Items != nil {
+		l.schema(pointer+"/items", *s.Items)
+	}
+
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		l.schema(pointer+"/additionalProperties", *s.AdditionalProperties.Schema)
+	}
+
+	compositions := []struct {
+		keyword string
+		schemas []*openapi.Schema
+	}{
+		{keyword: "allOf", schemas: s.AllOf},
+		{keyword: "anyOf", schemas: s.AnyOf},
+		{keyword: "oneOf", schemas: s.OneOf},
+	}
+
+	for _, c := range compositions {
+		for i, sub := range c.schemas {
+			if sub != nil {
+				l.schema(pointer+"/"+c.keyword+"/"+strconv.Itoa(i), *sub)
+			}
+		}
+	}
+
+	if s.Example != nil {
+		l.example(pointer+"/example", s, s.Example)
+	}
+}
+
+// example checks example against schema, schema with problems is skipped because its problems are already reported
+func (l *linter) example(pointer string, s openapi.Schema, example interface{}) {
+	s.Example = nil
+
+	schema, err := l.validator.convertSubschema(s)
+	if err != nil {
+		return
+	}
+
+	for _, e := range schema.Validate("", example) {
+		l.add(pointer+e.Pointer, e.Message)
+	}
+}
+
+// sortedKeys returns keys of map with string keys in order, so problems are listed in the same order on every run
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
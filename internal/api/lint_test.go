@@ -0,0 +1,209 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func TestProblem_String(t *testing.T) {
+	p := api.Problem{Path: "/paths/~1users/get", Message: "unknown type file"}
+
+	require.Equal(t, "#/paths/~1users/get: unknown type file", p.String())
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want []api.Problem
+	}{
+		{
+			name: "valid",
+			spec: `
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+              example:
+                - id: 1
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: integer
+`,
+			want: nil,
+		},
+		{
+			name: "unresolvable references",
+			spec: `
+paths:
+  /users/{id}:
+    parameters:
+      - $ref: '#/components/parameters/ID'
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+`,
+			want: []api.Problem{
+				{Path: "/paths/~1users~1{id}/parameters/0/$ref", Message: "unresolvable reference #/components/parameters/ID"},
+				{Path: "/paths/~1users~1{id}/get/responses/200/content/application~1json/schema/$ref", Message: "unresolvable reference #/components/schemas/User"},
+			},
+		},
+		{
+			name: "status code",
+			spec: `
+paths:
+  /users:
+    post:
+      responses:
+        created:
+          description: created
+`,
+			want: []api.Problem{
+				{Path: "/paths/~1users/post/responses/created", Message: "status code must be number, got created"},
+			},
+		},
+		{
+			name: "schemas",
+			spec: `
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  file:
+                    type: file
+                  tags:
+                    type: array
+            application/problem+json:
+              schema: {}
+            text/plain:
+              schema: {}
+`,
+			want: []api.Problem{
+				{Path: "/paths/~1users/get/responses/200/content/application~1json/schema/properties/file/type", Message: "unknown type file"},
+				{Path: "/paths/~1users/get/responses/200/content/application~1json/schema/properties/tags", Message: "array must have items"},
+				{Path: "/paths/~1users/get/responses/200/content/application~1problem+json/schema", Message: "schema must have type"},
+			},
+		},
+		{
+			name: "examples",
+			spec: `
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+              example:
+                id: one
+              examples:
+                second:
+                  value:
+                    name: 2
+components:
+  schemas:
+    User:
+      type: object
+      required:
+        - id
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+          example: 1
+`,
+			want: []api.Problem{
+				{Path: "/paths/~1users/get/responses/200/content/application~1json/example/id", Message: "must be integer, got string"},
+				{Path: "/paths/~1users/get/responses/200/content/application~1json/examples/second/value/id", Message: "required property is missing"},
+				{Path: "/paths/~1users/get/responses/200/content/application~1json/examples/second/value/name", Message: "must be string, got number"},
+				{Path: "/components/schemas/User/properties/name/example", Message: "must be string, got number"},
+			},
+		},
+		{
+			name: "headers",
+			spec: `
+paths:
+  /users:
+    post:
+      responses:
+        '201':
+          headers:
+            Location:
+              schema:
+                type: string
+              example: 1
+            X-Rate-Limit-Remaining:
+              $ref: '#/components/headers/Missing'
+components:
+  headers:
+    RateLimit:
+      schema:
+        type: integer
+      examples:
+        many:
+          value: lots
+`,
+			want: []api.Problem{
+				{Path: "/paths/~1users/post/responses/201/headers/Location/example", Message: "must be string, got number"},
+				{Path: "/paths/~1users/post/responses/201/headers/X-Rate-Limit-Remaining/$ref", Message: "unresolvable reference #/components/headers/Missing"},
+				{Path: "/components/headers/RateLimit/examples/many/value", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name: "servers",
+			spec: `
+servers:
+  - url: https://{env}.example.com/{version}
+    variables:
+      version:
+        enum:
+          - v1
+          - v2
+        default: v3
+paths: {}
+`,
+			want: []api.Problem{
+				{Path: "/servers/0/url", Message: "undefined variable env"},
+				{Path: "/servers/0/variables/version/default", Message: "must be one of v1, v2, got v3"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var oapi openapi.OpenAPI
+
+			require.NoError(t, yaml.Unmarshal([]byte(tc.spec), &oapi))
+			require.Equal(t, tc.want, api.Lint(oapi))
+		})
+	}
+}
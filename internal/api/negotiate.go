@@ -0,0 +1,159 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NotAcceptableError is returned if operation has no response with media type which is accepted by client
+type NotAcceptableError struct {
+	Accept     string
+	MediaTypes []string
+}
+
+// Error -.
+func (e *NotAcceptableError) Error() string {
+	return "media type must be one of " + strings.Join(e.MediaTypes, ", ") + ", got " + e.Accept
+}
+
+// mediaRange is media range of Accept header, e.g. text/* or application/json
+type mediaRange struct {
+	typ     string
+	subtype string
+	quality float64
+}
+
+// parseAccept returns media ranges of Accept header, empty header accepts any media type
+// Media ranges with wrong quality value are ignored
+func parseAccept(accept string) []mediaRange {
+	if strings.TrimSpace(accept) == "" {
+		return []mediaRange{{typ: "*", subtype: "*", quality: 1}}
+	}
+
+	ranges := make([]mediaRange, 0)
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+
+		typ, subtype := splitMediaType(params[0])
+		if typ == "" {
+			continue
+		}
+
+		r := mediaRange{typ: typ, subtype: subtype, quality: 1}
+		valid := true
+
+		for _, param := range params[1:] {
+			kv := strings.SplitN(param, "=", 2)
+			if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != "q" {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+
+				break
+			}
+
+			r.quality = q
+		}
+
+		if valid {
+			ranges = append(ranges, r)
+		}
+	}
+
+	return ranges
+}
+
+// splitMediaType returns lower-cased type and subtype of media type without parameters
+func splitMediaType(mediaType string) (string, string) {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+
+	parts := strings.SplitN(mediaType, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// specificity returns how specifically media range matches media type, zero if it does not match
+// Structured syntax suffix is matched by base media type, e.g. application/json matches application/problem+json
+func (r mediaRange) specificity(mediaType string) int {
+	typ, subtype := splitMediaType(mediaType)
+
+	switch {
+	case typ == "":
+		return 0
+	case r.typ == "*" && r.subtype == "*":
+		return 1
+	case r.typ != typ:
+		return 0
+	case r.subtype == "*":
+		return 2
+	case r.subtype == subtype:
+		return 4
+	}
+
+	if i := strings.LastIndex(subtype, "+"); i >= 0 && r.subtype == subtype[i+1:] {
+		return 3
+	}
+
+	return 0
+}
+
+// Negotiate returns media type which is preferred by Accept header, false if there is no acceptable media type
+// Quality of media type is quality of the most specific media range which matches it, ties are resolved
+// in favour of more specific media range, JSON and order of media types
+func Negotiate(accept string, mediaTypes []string) (string, bool) {
+	type candidate struct {
+		mediaType   string
+		quality     float64
+		specificity int
+		json        bool
+		index       int
+	}
+
+	ranges := parseAccept(accept)
+	candidates := make([]candidate, 0, len(mediaTypes))
+
+	for i, mediaType := range mediaTypes {
+		c := candidate{mediaType: mediaType, json: IsJSONMediaType(mediaType), index: i}
+
+		for _, r := range ranges {
+			specificity := r.specificity(mediaType)
+			if specificity > c.specificity {
+				c.specificity = specificity
+				c.quality = r.quality
+			}
+		}
+
+		if c.specificity > 0 && c.quality > 0 {
+			candidates = append(candidates, c)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+
+		switch {
+		case a.quality != b.quality:
+			return a.quality > b.quality
+		case a.specificity != b.specificity:
+			return a.specificity > b.specificity
+		case a.json != b.json:
+			return a.json
+		}
+
+		return a.index < b.index
+	})
+
+	return candidates[0].mediaType, true
+}
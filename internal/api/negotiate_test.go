@@ -0,0 +1,101 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+func TestNotAcceptableError(t *testing.T) {
+	err := &api.NotAcceptableError{Accept: "text/html", MediaTypes: []string{"application/json", "application/xml"}}
+
+	require.EqualError(t, err, "media type must be one of application/json, application/xml, got text/html")
+}
+
+func TestNegotiate(t *testing.T) {
+	mediaTypes := []string{"application/problem+json", "application/xml", "text/csv", "text/plain"}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+		ok     bool
+	}{
+		{
+			name: "empty accept prefers JSON",
+			want: "application/problem+json",
+			ok:   true,
+		},
+		{
+			name:   "any",
+			accept: "*/*",
+			want:   "application/problem+json",
+			ok:     true,
+		},
+		{
+			name:   "exact",
+			accept: "text/csv",
+			want:   "text/csv",
+			ok:     true,
+		},
+		{
+			name:   "exact with parameters",
+			accept: "Application/XML; charset=utf-8",
+			want:   "application/xml",
+			ok:     true,
+		},
+		{
+			name:   "suffix",
+			accept: "application/json",
+			want:   "application/problem+json",
+			ok:     true,
+		},
+		{
+			name:   "subtype wildcard prefers order",
+			accept: "text/*",
+			want:   "text/csv",
+			ok:     true,
+		},
+		{
+			name:   "quality",
+			accept: "application/xml;q=0.5, text/plain;q=0.9",
+			want:   "text/plain",
+			ok:     true,
+		},
+		{
+			name:   "specific range over any",
+			accept: "*/*, application/xml",
+			want:   "application/xml",
+			ok:     true,
+		},
+		{
+			name:   "most specific range sets quality",
+			accept: "text/*, text/csv;q=0, */*;q=0.1",
+			want:   "text/plain",
+			ok:     true,
+		},
+		{
+			name:   "zero quality",
+			accept: "application/xml;q=0",
+		},
+		{
+			name:   "not acceptable",
+			accept: "text/html",
+		},
+		{
+			name:   "wrong quality is ignored",
+			accept: "text/csv;q=2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := api.Negotiate(tc.accept, mediaTypes)
+
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
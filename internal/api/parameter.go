@@ -0,0 +1,340 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Parameter locations
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InCookie = "cookie"
+)
+
+// Parameter styles
+const (
+	StyleSimple         = "simple"
+	StyleLabel          = "label"
+	StyleMatrix         = "matrix"
+	StyleForm           = "form"
+	StyleSpaceDelimited = "spaceDelimited"
+	StylePipeDelimited  = "pipeDelimited"
+	StyleDeepObject     = "deepObject"
+)
+
+// DefaultStyle returns default style of parameter in location
+func DefaultStyle(in string) string {
+	switch in {
+	case InQuery, InCookie:
+		return StyleForm
+	default:
+		return StyleSimple
+	}
+}
+
+// ValidateParameters returns violations of operation parameters by request with values of path parameters of route
+func (o Operation) ValidateParameters(params FindResponseParams, path map[string]string) ValidationErrors {
+	var errs ValidationErrors
+
+	cookies := (&http.Request{Header: params.Header}).Cookies()
+
+	for _, p := range o.Parameters {
+		var (
+			value interface{}
+			ok    bool
+		)
+
+		switch p.In {
+		case InPath:
+			value, ok = p.decodePath(path)
+		case InQuery:
+			value, ok = p.decodeQuery(params.Query)
+		case InHeader:
+			value, ok = p.decodeHeader(params.Header)
+		case InCookie:
+			value, ok = p.decodeCookie(cookies)
+		}
+
+		if !ok {
+			if p.Required {
+				errs = append(errs, ValidationError{
+					In:        p.In,
+					Parameter: p.Name,
+					Message:   "required parameter is missing",
+				})
+			}
+
+			continue
+		}
+
+		for _, err := range p.Schema.Validate("", Coerce(p.Schema, value)) {
+			err.In = p.In
+			err.Parameter = p.Name
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// PathParams returns values of path parameters by path template
+func PathParams(path, pathTemplate string) map[string]string {
+	pathSegments := strings.Split(path, "/")
+	templateSegments := strings.Split(pathTemplate, "/")
+
+	params := make(map[string]string)
+
+	for i := 0; i < len(pathSegments) && i < len(templateSegments); i++ {
+		if strings.HasPrefix(templateSegments[i], "{") && strings.HasSuffix(templateSegments[i], "}") {
+			params[templateSegments[i][1:len(templateSegments[i])-1]] = pathSegments[i]
+		}
+	}
+
+	return params
+}
+
+func (p Parameter) decodePath(path map[string]string) (interface{}, bool) {
+	raw, ok := path[p.Name]
+	if !ok {
+		return nil, false
+	}
+
+	switch p.Style {
+	case StyleLabel:
+		raw = strings.TrimPrefix(raw, ".")
+
+		if p.Explode {
+			switch schemaKind(p.Schema) {
+			case "array":
+				return list(strings.Split(raw, ".")), true
+			case "object":
+				return pairs(strings.Split(raw, ".")), true
+			}
+		}
+	case StyleMatrix:
+		raw = strings.TrimPrefix(raw, ";")
+
+		if p.Explode {
+			switch schemaKind(p.Schema) {
+			case "array":
+				parts := strings.Split(raw, ";")
+
+				for i, part := range parts {
+					parts[i] = strings.TrimPrefix(part, p.Name+"=")
+				}
+
+				return list(parts), true
+			case "object":
+				return pairs(strings.Split(raw, ";")), true
+			}
+		}
+
+		raw = strings.TrimPrefix(raw, p.Name+"=")
+	}
+
+	return p.decodeDelimited(raw, ","), true
+}
+
+func (p Parameter) decodeQuery(query url.Values) (interface{}, bool) {
+	if schemaKind(p.Schema) == "object" {
+		switch {
+		case p.Style == StyleDeepObject:
+			return deepObject(p.Name, query)
+		case p.Style == StyleForm && p.Explode:
+			return formObject(p.Schema, query)
+		}
+	}
+
+	values, ok := query[p.Name]
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+
+	if schemaKind(p.Schema) == "array" && p.Style == StyleForm && p.Explode {
+		return list(values), true
+	}
+
+	switch p.Style {
+	case StyleSpaceDelimited:
+		return p.decodeDelimited(values[0], " "), true
+	case StylePipeDelimited:
+		return p.decodeDelimited(values[0], "|"), true
+	default:
+		return p.decodeDelimited(values[0], ","), true
+	}
+}
+
+func (p Parameter) decodeHeader(header http.Header) (interface{}, bool) {
+	values := header.Values(p.Name)
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	parts := strings.Split(strings.Join(values, ","), ",")
+
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+
+	return p.decodeDelimited(strings.Join(parts, ","), ","), true
+}
+
+func (p Parameter) decodeCookie(cookies []*http.Cookie) (interface{}, bool) {
+	for _, c := range cookies {
+		if c.Name == p.Name {
+			return p.decodeDelimited(c.Value, ","), true
+		}
+	}
+
+	return nil, false
+}
+
+// decodeDelimited decodes primitive, list of values or object serialized as delimited keys and values
+// Exploded object is serialized as key=value pairs, e.g. role=admin,firstName=Alex
+func (p Parameter) decodeDelimited(raw, sep string) interface{} {
+	switch schemaKind(p.Schema) {
+	case "array":
+		if raw == "" {
+			return []interface{}{}
+		}
+
+		return list(strings.Split(raw, sep))
+	case "object":
+		parts := strings.Split(raw, sep)
+
+		if p.Explode {
+			return pairs(parts)
+		}
+
+		obj := make(map[string]interface{}, len(parts)/2)
+
+		for i := 0; i+1 < len(parts); i += 2 {
+			obj[parts[i]] = parts[i+1]
+		}
+
+		return obj
+	default:
+		return raw
+	}
+}
+
+func deepObject(name string, query url.Values) (interface{}, bool) {
+	obj := make(map[string]interface{})
+
+	for key, values := range query {
+		if !strings.HasPrefix(key, name+"[") || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+
+		obj[key[len(name)+1:len(key)-1]] = values[0]
+	}
+
+	return obj, len(obj) > 0
+}
+
+// formObject collects exploded object from query, every property is separate query parameter
+func formObject(s Schema, query url.Values) (interface{}, bool) {
+	obj := make(map[string]interface{})
+
+	if o, ok := Unwrap(s).(ObjectSchema); ok {
+		for key := range o.Properties {
+			if values, ok := query[key]; ok && len(values) > 0 {
+				obj[key] = values[0]
+			}
+		}
+	}
+
+	return obj, len(obj) > 0
+}
+
+func list(values []string) []interface{} {
+	res := make([]interface{}, len(values))
+
+	for i, v := range values {
+		res[i] = v
+	}
+
+	return res
+}
+
+func pairs(parts []string) map[string]interface{} {
+	obj := make(map[string]interface{}, len(parts))
+
+	for _, part := range parts {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			obj[kv[0]] = kv[1]
+		}
+	}
+
+	return obj
+}
+
+// Unwrap returns schema of nullable schema, other schemas are returned as is
+func Unwrap(s Schema) Schema {
+	if n, ok := s.(NullableSchema); ok {
+		return n.Schema
+	}
+
+	return s
+}
+
+func schemaKind(s Schema) string {
+	switch Unwrap(s).(type) {
+	case ArraySchema:
+		return "array"
+	case ObjectSchema:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// Coerce converts string values of parameter to types of schema, value is kept as is if it cannot be converted
+func Coerce(s Schema, value interface{}) interface{} {
+	switch s := Unwrap(s).(type) {
+	case IntSchema, FloatSchema:
+		if str, ok := value.(string); ok {
+			if n, err := strconv.ParseFloat(str, 64); err == nil {
+				return n
+			}
+		}
+	case BooleanSchema:
+		if str, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(str); err == nil {
+				return b
+			}
+		}
+	case ArraySchema:
+		if items, ok := value.([]interface{}); ok {
+			res := make([]interface{}, len(items))
+
+			for i, item := range items {
+				res[i] = Coerce(s.Type, item)
+			}
+
+			return res
+		}
+	case ObjectSchema:
+		if obj, ok := value.(map[string]interface{}); ok {
+			res := make(map[string]interface{}, len(obj))
+
+			for key, v := range obj {
+				schema, ok := s.Properties[key]
+				if !ok {
+					schema = s.AdditionalProperties
+				}
+
+				res[key] = Coerce(schema, v)
+			}
+
+			return res
+		}
+	}
+
+	return value
+}
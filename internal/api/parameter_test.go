@@ -0,0 +1,179 @@
+package api_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+func TestPathParams(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"userId": "1",
+		"postId": "2",
+	}, api.PathParams("/users/1/posts/2", "/users/{userId}/posts/{postId}"))
+}
+
+func TestOperation_ValidateParameters(t *testing.T) {
+	intArray := api.ArraySchema{Type: api.IntSchema{}}
+	point := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"x": api.IntSchema{},
+			"y": api.IntSchema{},
+		},
+		Required: []string{"x", "y"},
+	}
+
+	tests := []struct {
+		name      string
+		template  string
+		parameter api.Parameter
+		path      string
+		query     string
+		header    http.Header
+		want      api.ValidationErrors
+	}{
+		{
+			name:      "path integer",
+			template:  "/users/{userId}",
+			parameter: api.Parameter{Name: "userId", In: api.InPath, Required: true, Style: api.StyleSimple, Schema: api.IntSchema{}},
+			path:      "/users/1",
+			want:      nil,
+		},
+		{
+			name:      "path wrong type",
+			template:  "/users/{userId}",
+			parameter: api.Parameter{Name: "userId", In: api.InPath, Required: true, Style: api.StyleSimple, Schema: api.IntSchema{}},
+			path:      "/users/abc",
+			want: api.ValidationErrors{
+				{In: "path", Parameter: "userId", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name:      "path simple array",
+			template:  "/points/{ids}",
+			parameter: api.Parameter{Name: "ids", In: api.InPath, Required: true, Style: api.StyleSimple, Schema: intArray},
+			path:      "/points/1,x",
+			want: api.ValidationErrors{
+				{In: "path", Parameter: "ids", Pointer: "/1", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name:      "path label exploded array",
+			template:  "/points/{ids}",
+			parameter: api.Parameter{Name: "ids", In: api.InPath, Style: api.StyleLabel, Explode: true, Schema: intArray},
+			path:      "/points/.1.2",
+			want:      nil,
+		},
+		{
+			name:      "path matrix object",
+			template:  "/points/{point}",
+			parameter: api.Parameter{Name: "point", In: api.InPath, Style: api.StyleMatrix, Schema: point},
+			path:      "/points/;point=x,1,y,2",
+			want:      nil,
+		},
+		{
+			name:      "path matrix exploded object",
+			template:  "/points/{point}",
+			parameter: api.Parameter{Name: "point", In: api.InPath, Style: api.StyleMatrix, Explode: true, Schema: point},
+			path:      "/points/;x=1",
+			want: api.ValidationErrors{
+				{In: "path", Parameter: "point", Pointer: "/y", Message: "required property is missing"},
+			},
+		},
+		{
+			name:      "query required",
+			template:  "/users",
+			parameter: api.Parameter{Name: "limit", In: api.InQuery, Required: true, Style: api.StyleForm, Explode: true, Schema: api.IntSchema{}},
+			path:      "/users",
+			want: api.ValidationErrors{
+				{In: "query", Parameter: "limit", Message: "required parameter is missing"},
+			},
+		},
+		{
+			name:      "query exploded array",
+			template:  "/users",
+			parameter: api.Parameter{Name: "id", In: api.InQuery, Style: api.StyleForm, Explode: true, Schema: intArray},
+			path:      "/users",
+			query:     "id=1&id=2",
+			want:      nil,
+		},
+		{
+			name:      "query form array",
+			template:  "/users",
+			parameter: api.Parameter{Name: "id", In: api.InQuery, Style: api.StyleForm, Schema: intArray},
+			path:      "/users",
+			query:     "id=1,2",
+			want:      nil,
+		},
+		{
+			name:      "query pipe delimited array",
+			template:  "/users",
+			parameter: api.Parameter{Name: "id", In: api.InQuery, Style: api.StylePipeDelimited, Schema: intArray},
+			path:      "/users",
+			query:     "id=1|a",
+			want: api.ValidationErrors{
+				{In: "query", Parameter: "id", Pointer: "/1", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name:      "query exploded object",
+			template:  "/points",
+			parameter: api.Parameter{Name: "point", In: api.InQuery, Required: true, Style: api.StyleForm, Explode: true, Schema: point},
+			path:      "/points",
+			query:     "x=1&y=2",
+			want:      nil,
+		},
+		{
+			name:      "query deep object",
+			template:  "/points",
+			parameter: api.Parameter{Name: "point", In: api.InQuery, Style: api.StyleDeepObject, Explode: true, Schema: point},
+			path:      "/points",
+			query:     "point[x]=1&point[y]=a",
+			want: api.ValidationErrors{
+				{In: "query", Parameter: "point", Pointer: "/y", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name:      "header",
+			template:  "/users",
+			parameter: api.Parameter{Name: "X-Rate", In: api.InHeader, Required: true, Style: api.StyleSimple, Schema: api.FloatSchema{}},
+			path:      "/users",
+			header:    http.Header{"X-Rate": {"fast"}},
+			want: api.ValidationErrors{
+				{In: "header", Parameter: "X-Rate", Message: "must be number, got string"},
+			},
+		},
+		{
+			name:      "cookie",
+			template:  "/users",
+			parameter: api.Parameter{Name: "debug", In: api.InCookie, Required: true, Style: api.StyleForm, Explode: true, Schema: api.BooleanSchema{}},
+			path:      "/users",
+			header:    http.Header{"Cookie": {"debug=true"}},
+			want:      nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+
+			o := api.Operation{
+				Path:       tc.template,
+				Parameters: []api.Parameter{tc.parameter},
+			}
+
+			got := o.ValidateParameters(api.FindResponseParams{
+				Path:   tc.path,
+				Query:  query,
+				Header: tc.header,
+			}, api.PathParams(tc.path, tc.template))
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MethodNotAllowedError is returned if path matches path template which has no operation for method
+type MethodNotAllowedError struct {
+	Method string
+	Path   string
+	// Allow are methods of path ordered by name
+	Allow []string
+}
+
+// Error -.
+func (e *MethodNotAllowedError) Error() string {
+	return "method " + e.Method + " is not allowed for " + e.Path + ", allowed methods: " + strings.Join(e.Allow, ", ")
+}
+
+// Route is operation found by path and method with values of path parameters
+type Route struct {
+	Operation Operation
+	Params    map[string]string
+}
+
+// Router is tree of path template segments, it is built once from operations of API
+type Router struct {
+	root *node
+}
+
+type node struct {
+	// literals are children by segment, param is child for path parameter of any name
+	literals map[string]*node
+	param    *node
+	// operations are operations by method of path template which ends at node
+	operations map[string]Operation
+}
+
+// NewRouter returns router of operations, the first operation is kept if path template and method are repeated
+// Path template with GET operation has HEAD operation if specification does not define it
+func NewRouter(operations []Operation) *Router {
+	r := &Router{root: &node{}}
+
+	for _, o := range operations {
+		n := r.root
+
+		for _, segment := range strings.Split(o.Path, "/") {
+			n = n.child(segment)
+		}
+
+		if nil == n.operations {
+			n.operations = make(map[string]Operation)
+		}
+
+		if _, ok := n.operations[o.Method]; !ok {
+			n.operations[o.Method] = o
+		}
+	}
+
+	r.root.complete()
+
+	return r
+}
+
+// complete adds automatic HEAD operations to nodes with GET operation
+func (n *node) complete() {
+	for _, child := range n.literals {
+		child.complete()
+	}
+
+	if n.param != nil {
+		n.param.complete()
+	}
+
+	if get, ok := n.operations[http.MethodGet]; ok {
+		if _, ok := n.operations[http.MethodHead]; !ok {
+			n.operations[http.MethodHead] = head(get)
+		}
+	}
+}
+
+// head returns HEAD operation of GET operation, it has the same parameters and headers of responses
+// Body of response to HEAD request is discarded by HTTP server
+func head(get Operation) Operation {
+	o := get
+	o.Method = http.MethodHead
+
+	return o
+}
+
+// options returns OPTIONS operation which responds with methods of path in Allow header
+func options(path string, methods []string) Operation {
+	return Operation{
+		Method: http.MethodOptions,
+		Path:   path,
+		Responses: []Response{
+			{
+				StatusCode: http.StatusNoContent,
+				Headers: []Header{
+					{Name: "Allow", Required: true, Schema: StringSchema{}, Example: strings.Join(methods, ", ")},
+				},
+			},
+		},
+	}
+}
+
+func (n *node) child(segment string) *node {
+	if isParam(segment) {
+		if nil == n.param {
+			n.param = &node{}
+		}
+
+		return n.param
+	}
+
+	if nil == n.literals {
+		n.literals = make(map[string]*node)
+	}
+
+	child, ok := n.literals[segment]
+	if !ok {
+		child = &node{}
+		n.literals[segment] = child
+	}
+
+	return child
+}
+
+// Find returns operation for path and method, literal segments take precedence over path parameters,
+// so /users/me is found before /users/{userId}
+// OPTIONS operation which lists methods of path is returned if specification does not define it
+// FindResponseError is returned if path is not specified and MethodNotAllowedError if path has no operation for method
+func (r *Router) Find(path, method string) (Route, error) {
+	var (
+		route    Route
+		found    bool
+		template string
+		allow    = map[string]struct{}{http.MethodOptions: {}}
+	)
+
+	r.root.walk(strings.Split(path, "/"), func(n *node) bool {
+		if o, ok := n.operations[method]; ok {
+			route = Route{Operation: o, Params: PathParams(path, o.Path)}
+			found = true
+
+			return true
+		}
+
+		for m, o := range n.operations {
+			allow[m] = struct{}{}
+
+			if template == "" {
+				template = o.Path
+			}
+		}
+
+		return false
+	})
+
+	if found {
+		return route, nil
+	}
+
+	if template == "" {
+		return Route{}, &FindResponseError{Method: method, Path: path}
+	}
+
+	methods := make([]string, 0, len(allow))
+	for m := range allow {
+		methods = append(methods, m)
+	}
+
+	sort.Strings(methods)
+
+	if method == http.MethodOptions {
+		return Route{Operation: options(template, methods), Params: PathParams(path, template)}, nil
+	}
+
+	return Route{}, &MethodNotAllowedError{Method: method, Path: path, Allow: methods}
+}
+
+// walk visits nodes with operations which match segments in order of precedence until visit returns true
+func (n *node) walk(segments []string, visit func(*node) bool) bool {
+	if len(segments) == 0 {
+		return len(n.operations) > 0 && visit(n)
+	}
+
+	if child, ok := n.literals[segments[0]]; ok && child.walk(segments[1:], visit) {
+		return true
+	}
+
+	return n.param != nil && n.param.walk(segments[1:], visit)
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
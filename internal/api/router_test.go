@@ -0,0 +1,211 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+func TestMethodNotAllowedError(t *testing.T) {
+	err := &api.MethodNotAllowedError{Method: "PUT", Path: "/users", Allow: []string{"GET", "POST"}}
+
+	require.EqualError(t, err, "method PUT is not allowed for /users, allowed methods: GET, POST")
+}
+
+func TestRouter_Find(t *testing.T) {
+	operations := []api.Operation{
+		{Method: http.MethodGet, Path: "/users/{userId}"},
+		{Method: http.MethodDelete, Path: "/users/{userId}"},
+		{Method: http.MethodGet, Path: "/users/me"},
+		{Method: http.MethodGet, Path: "/users/{userId}/posts/{postId}"},
+		{Method: http.MethodGet, Path: "/users"},
+		{Method: http.MethodPost, Path: "/users"},
+		{Method: http.MethodGet, Path: "/users/{id}", Responses: []api.Response{{StatusCode: http.StatusTeapot}}},
+		{Method: http.MethodGet, Path: "/"},
+		{Method: http.MethodHead, Path: "/users", Responses: []api.Response{{StatusCode: http.StatusNoContent}}},
+		{Method: http.MethodOptions, Path: "/users/{userId}/posts/{postId}", Responses: []api.Response{{StatusCode: http.StatusOK}}},
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		method string
+		want   api.Route
+		err    error
+	}{
+		{
+			name:   "literal",
+			path:   "/users",
+			method: http.MethodPost,
+			want:   api.Route{Operation: operations[5], Params: map[string]string{}},
+		},
+		{
+			name:   "root",
+			path:   "/",
+			method: http.MethodGet,
+			want:   api.Route{Operation: operations[7], Params: map[string]string{}},
+		},
+		{
+			name:   "literal before path parameter",
+			path:   "/users/me",
+			method: http.MethodGet,
+			want:   api.Route{Operation: operations[2], Params: map[string]string{}},
+		},
+		{
+			name:   "path parameter",
+			path:   "/users/42",
+			method: http.MethodGet,
+			want:   api.Route{Operation: operations[0], Params: map[string]string{"userId": "42"}},
+		},
+		{
+			name:   "path parameter if literal has no method",
+			path:   "/users/me",
+			method: http.MethodDelete,
+			want:   api.Route{Operation: operations[1], Params: map[string]string{"userId": "me"}},
+		},
+		{
+			name:   "path parameter if literal has no path",
+			path:   "/users/me/posts/1",
+			method: http.MethodGet,
+			want:   api.Route{Operation: operations[3], Params: map[string]string{"userId": "me", "postId": "1"}},
+		},
+		{
+			name:   "not specified path",
+			path:   "/users/42/comments",
+			method: http.MethodGet,
+			err:    &api.FindResponseError{Method: http.MethodGet, Path: "/users/42/comments"},
+		},
+		{
+			name:   "not specified method",
+			path:   "/users/me",
+			method: http.MethodPatch,
+			err: &api.MethodNotAllowedError{
+				Method: http.MethodPatch,
+				Path:   "/users/me",
+				Allow:  []string{"DELETE", "GET", "HEAD", "OPTIONS"},
+			},
+		},
+		{
+			name:   "automatic HEAD",
+			path:   "/users/42",
+			method: http.MethodHead,
+			want: api.Route{
+				Operation: api.Operation{Method: http.MethodHead, Path: "/users/{userId}"},
+				Params:    map[string]string{"userId": "42"},
+			},
+		},
+		{
+			name:   "specified HEAD",
+			path:   "/users",
+			method: http.MethodHead,
+			want:   api.Route{Operation: operations[8], Params: map[string]string{}},
+		},
+		{
+			name:   "automatic OPTIONS",
+			path:   "/users/me",
+			method: http.MethodOptions,
+			want: api.Route{
+				Operation: api.Operation{
+					Method: http.MethodOptions,
+					Path:   "/users/me",
+					Responses: []api.Response{
+						{
+							StatusCode: http.StatusNoContent,
+							Headers: []api.Header{
+								{Name: "Allow", Required: true, Schema: api.StringSchema{}, Example: "DELETE, GET, HEAD, OPTIONS"},
+							},
+						},
+					},
+				},
+				Params: map[string]string{},
+			},
+		},
+		{
+			name:   "specified OPTIONS",
+			path:   "/users/1/posts/2",
+			method: http.MethodOptions,
+			want:   api.Route{Operation: operations[9], Params: map[string]string{"userId": "1", "postId": "2"}},
+		},
+		{
+			name:   "OPTIONS of not specified path",
+			path:   "/posts",
+			method: http.MethodOptions,
+			err:    &api.FindResponseError{Method: http.MethodOptions, Path: "/posts"},
+		},
+	}
+
+	r := api.NewRouter(operations)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := r.Find(tc.path, tc.method)
+
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAPI_FindOperation(t *testing.T) {
+	operations := []api.Operation{
+		{Method: http.MethodGet, Path: "/users/{userId}"},
+		{Method: http.MethodGet, Path: "/users/me"},
+	}
+
+	for _, a := range []api.API{api.NewAPI(operations), {Operations: operations}} {
+		got, ok := a.FindOperation("/users/me", http.MethodGet)
+
+		require.True(t, ok)
+		require.Equal(t, "/users/me", got.Path)
+	}
+}
+
+func resources(n int) []api.Operation {
+	operations := make([]api.Operation, 0, 5*n)
+
+	for i := 0; i < n; i++ {
+		collection := "/resources" + strconv.Itoa(i)
+
+		operations = append(operations,
+			api.Operation{Method: http.MethodGet, Path: collection},
+			api.Operation{Method: http.MethodPost, Path: collection},
+			api.Operation{Method: http.MethodGet, Path: collection + "/{id}"},
+			api.Operation{Method: http.MethodDelete, Path: collection + "/{id}"},
+			api.Operation{Method: http.MethodGet, Path: collection + "/{id}/items/{itemId}"},
+		)
+	}
+
+	return operations
+}
+
+func BenchmarkRouter_Find(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		r := api.NewRouter(resources(n))
+		path := fmt.Sprintf("/resources%d/42/items/7", n-1)
+
+		b.Run(strconv.Itoa(5*n)+" operations", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := r.Find(path, http.MethodGet); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewRouter(b *testing.B) {
+	operations := resources(1000)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		api.NewRouter(operations)
+	}
+}
@@ -0,0 +1,404 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError is violation of schema by value located by JSON pointer
+// In and Parameter are set for violations of request parameters
+type ValidationError struct {
+	In        string `json:"in,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Pointer   string `json:"pointer"`
+	Message   string `json:"message"`
+}
+
+// Error -.
+func (e ValidationError) Error() string {
+	if e.Parameter != "" {
+		return e.In + " parameter " + e.Parameter + e.Pointer + ": " + e.Message
+	}
+
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return pointer + ": " + e.Message
+}
+
+// ValidationErrors -.
+type ValidationErrors []ValidationError
+
+// Error -.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func violation(pointer, format string, args ...interface{}) ValidationErrors {
+	return ValidationErrors{{Pointer: pointer, Message: fmt.Sprintf(format, args...)}}
+}
+
+// JSONPointer returns JSON pointer to child token of pointer
+func JSONPointer(pointer, token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+
+	return pointer + "/" + token
+}
+
+// Validate -.
+func (b BooleanSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	if _, ok := value.(bool); !ok {
+		return typeViolation(pointer, "boolean", value)
+	}
+
+	return nil
+}
+
+// Validate -.
+func (i IntSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	n, ok := toFloat64(value)
+	if !ok || n != math.Trunc(n) {
+		return typeViolation(pointer, "integer", value)
+	}
+
+	errs := validateNumber(pointer, n, numberConstraints{
+		minimum:          i.Minimum,
+		maximum:          i.Maximum,
+		exclusiveMinimum: i.ExclusiveMinimum,
+		exclusiveMaximum: i.ExclusiveMaximum,
+		multipleOf:       i.MultipleOf,
+	})
+
+	return append(errs, validateEnum(pointer, i.Enum, value)...)
+}
+
+// Validate -.
+func (f FloatSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	n, ok := toFloat64(value)
+	if !ok {
+		return typeViolation(pointer, "number", value)
+	}
+
+	errs := validateNumber(pointer, n, numberConstraints{
+		minimum:          f.Minimum,
+		maximum:          f.Maximum,
+		exclusiveMinimum: f.ExclusiveMinimum,
+		exclusiveMaximum: f.ExclusiveMaximum,
+		multipleOf:       f.MultipleOf,
+	})
+
+	return append(errs, validateEnum(pointer, f.Enum, value)...)
+}
+
+type numberConstraints struct {
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum bool
+	exclusiveMaximum bool
+	multipleOf       *float64
+}
+
+func validateNumber(pointer string, n float64, c numberConstraints) ValidationErrors {
+	var errs ValidationErrors
+
+	if c.minimum != nil {
+		if c.exclusiveMinimum && n <= *c.minimum {
+			errs = append(errs, violation(pointer, "must be greater than %v", *c.minimum)...)
+		} else if n < *c.minimum {
+			errs = append(errs, violation(pointer, "must be greater than or equal to %v", *c.minimum)...)
+		}
+	}
+
+	if c.maximum != nil {
+		if c.exclusiveMaximum && n >= *c.maximum {
+			errs = append(errs, violation(pointer, "must be less than %v", *c.maximum)...)
+		} else if n > *c.maximum {
+			errs = append(errs, violation(pointer, "must be less than or equal to %v", *c.maximum)...)
+		}
+	}
+
+	if c.multipleOf != nil && *c.multipleOf > 0 {
+		q := n / *c.multipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			errs = append(errs, violation(pointer, "must be multiple of %v", *c.multipleOf)...)
+		}
+	}
+
+	return errs
+}
+
+// Validate -.
+func (s StringSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	str, ok := value.(string)
+	if !ok {
+		return typeViolation(pointer, "string", value)
+	}
+
+	var errs ValidationErrors
+
+	length := uint64(utf8.RuneCountInString(str))
+
+	if s.MinLength != nil && length < *s.MinLength {
+		errs = append(errs, violation(pointer, "length must be greater than or equal to %d", *s.MinLength)...)
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		errs = append(errs, violation(pointer, "length must be less than or equal to %d", *s.MaxLength)...)
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err == nil && !re.MatchString(str) {
+			errs = append(errs, violation(pointer, "must match pattern %q", s.Pattern)...)
+		}
+	}
+
+	if !IsFormat(s.Format, str) {
+		errs = append(errs, violation(pointer, "must be valid %s", s.Format)...)
+	}
+
+	return append(errs, validateEnum(pointer, s.Enum, value)...)
+}
+
+var (
+	uuidRegexp     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+// IsFormat returns true if value matches string format, unknown formats match any value
+func IsFormat(format, value string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case "uuid":
+		return uuidRegexp.MatchString(value)
+	case "uri", "url":
+		u, err := url.Parse(value)
+		return err == nil && u.IsAbs()
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && strings.Contains(value, ".")
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && strings.Contains(value, ":")
+	case "hostname":
+		return len(value) <= 253 && hostnameRegexp.MatchString(value)
+	case "byte":
+		_, err := base64.StdEncoding.DecodeString(value)
+		return err == nil
+	}
+
+	return true
+}
+
+// Validate -.
+func (a ArraySchema) Validate(pointer string, value interface{}) ValidationErrors {
+	list, ok := value.([]interface{})
+	if !ok {
+		return typeViolation(pointer, "array", value)
+	}
+
+	var errs ValidationErrors
+
+	length := uint64(len(list))
+
+	if a.MinItems != nil && length < *a.MinItems {
+		errs = append(errs, violation(pointer, "must contain at least %d items", *a.MinItems)...)
+	}
+
+	if a.MaxItems != nil && length > *a.MaxItems {
+		errs = append(errs, violation(pointer, "must contain at most %d items", *a.MaxItems)...)
+	}
+
+	if a.UniqueItems {
+		for i := 0; i < len(list); i++ {
+			for j := i + 1; j < len(list); j++ {
+				if equal(list[i], list[j]) {
+					errs = append(errs, violation(pointer, "items %d and %d must be unique", i, j)...)
+				}
+			}
+		}
+	}
+
+	if a.Type != nil {
+		for i, item := range list {
+			errs = append(errs, a.Type.Validate(JSONPointer(pointer, strconv.Itoa(i)), item)...)
+		}
+	}
+
+	return errs
+}
+
+// Validate -.
+func (o ObjectSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return typeViolation(pointer, "object", value)
+	}
+
+	var errs ValidationErrors
+
+	for _, key := range o.Required {
+		if _, ok := obj[key]; !ok {
+			errs = append(errs, violation(JSONPointer(pointer, key), "required property is missing")...)
+		}
+	}
+
+	count := uint64(len(obj))
+
+	if o.MinProperties != nil && count < *o.MinProperties {
+		errs = append(errs, violation(pointer, "must contain at least %d properties", *o.MinProperties)...)
+	}
+
+	if o.MaxProperties != nil && count > *o.MaxProperties {
+		errs = append(errs, violation(pointer, "must contain at most %d properties", *o.MaxProperties)...)
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		schema, ok := o.Properties[key]
+		if !ok {
+			schema = o.AdditionalProperties
+		}
+
+		if nil == schema {
+			continue
+		}
+
+		if _, ok := schema.(FalseSchema); ok {
+			errs = append(errs, violation(JSONPointer(pointer, key), "additional property is not allowed")...)
+
+			continue
+		}
+
+		errs = append(errs, schema.Validate(JSONPointer(pointer, key), obj[key])...)
+	}
+
+	return errs
+}
+
+// Validate -.
+func (f FakerSchema) Validate(string, interface{}) ValidationErrors {
+	return nil
+}
+
+// Validate -.
+func (AnySchema) Validate(string, interface{}) ValidationErrors {
+	return nil
+}
+
+// Validate -.
+func (FalseSchema) Validate(pointer string, _ interface{}) ValidationErrors {
+	return violation(pointer, "value is not allowed")
+}
+
+// Validate -.
+func (n NullableSchema) Validate(pointer string, value interface{}) ValidationErrors {
+	if nil == value {
+		return nil
+	}
+
+	return n.Schema.Validate(pointer, value)
+}
+
+func typeViolation(pointer, expected string, value interface{}) ValidationErrors {
+	return violation(pointer, "must be %s, got %s", expected, jsonType(value))
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	if _, ok := toFloat64(value); ok {
+		return "number"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func validateEnum(pointer string, enum []interface{}, value interface{}) ValidationErrors {
+	if len(enum) == 0 {
+		return nil
+	}
+
+	for _, e := range enum {
+		if equal(e, value) {
+			return nil
+		}
+	}
+
+	return violation(pointer, "must be one of %v", enum)
+}
+
+// equal compares JSON values, numbers of different Go types are equal if they have the same value
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(v interface{}) interface{} {
+	if n, ok := toFloat64(v); ok {
+		return n
+	}
+
+	switch v := v.(type) {
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = normalize(item)
+		}
+
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			res[k] = normalize(item)
+		}
+
+		return res
+	}
+
+	return v
+}
@@ -0,0 +1,212 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	errs := api.ValidationErrors{
+		{Pointer: "", Message: "must be object, got array"},
+		{Pointer: "/name", Message: "required property is missing"},
+	}
+
+	require.Equal(t, "/: must be object, got array; /name: required property is missing", errs.Error())
+}
+
+func TestJSONPointer(t *testing.T) {
+	require.Equal(t, "/a~1b/m~0n", api.JSONPointer(api.JSONPointer("", "a/b"), "m~n"))
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema api.Schema
+		value  interface{}
+		want   api.ValidationErrors
+	}{
+		{
+			name:   "boolean",
+			schema: api.BooleanSchema{},
+			value:  true,
+			want:   nil,
+		},
+		{
+			name:   "boolean type",
+			schema: api.BooleanSchema{},
+			value:  "true",
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be boolean, got string"}},
+		},
+		{
+			name:   "integer",
+			schema: api.IntSchema{Minimum: float64Ptr(1), Maximum: float64Ptr(10)},
+			value:  float64(5),
+			want:   nil,
+		},
+		{
+			name:   "integer with fraction",
+			schema: api.IntSchema{},
+			value:  1.5,
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be integer, got number"}},
+		},
+		{
+			name:   "integer minimum",
+			schema: api.IntSchema{Minimum: float64Ptr(1)},
+			value:  float64(0),
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be greater than or equal to 1"}},
+		},
+		{
+			name:   "integer exclusive maximum",
+			schema: api.IntSchema{Maximum: float64Ptr(10), ExclusiveMaximum: true},
+			value:  float64(10),
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be less than 10"}},
+		},
+		{
+			name:   "number multiple of",
+			schema: api.FloatSchema{MultipleOf: float64Ptr(0.5)},
+			value:  0.7,
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be multiple of 0.5"}},
+		},
+		{
+			name:   "number enum",
+			schema: api.FloatSchema{Enum: []interface{}{uint64(1), 2.5}},
+			value:  float64(1),
+			want:   nil,
+		},
+		{
+			name:   "string length",
+			schema: api.StringSchema{MinLength: uint64Ptr(2), MaxLength: uint64Ptr(3)},
+			value:  "абвг",
+			want:   api.ValidationErrors{{Pointer: "", Message: "length must be less than or equal to 3"}},
+		},
+		{
+			name:   "string pattern",
+			schema: api.StringSchema{Pattern: "^[a-z]+$"},
+			value:  "Abc",
+			want:   api.ValidationErrors{{Pointer: "", Message: `must match pattern "^[a-z]+$"`}},
+		},
+		{
+			name:   "string format",
+			schema: api.StringSchema{Format: "uuid"},
+			value:  "not-uuid",
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be valid uuid"}},
+		},
+		{
+			name:   "string enum",
+			schema: api.StringSchema{Enum: []interface{}{"a", "b"}},
+			value:  "c",
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be one of [a b]"}},
+		},
+		{
+			name:   "array items",
+			schema: api.ArraySchema{Type: api.StringSchema{}, MaxItems: uint64Ptr(2)},
+			value:  []interface{}{"a", float64(1), "c"},
+			want: api.ValidationErrors{
+				{Pointer: "", Message: "must contain at most 2 items"},
+				{Pointer: "/1", Message: "must be string, got number"},
+			},
+		},
+		{
+			name:   "array unique items",
+			schema: api.ArraySchema{Type: api.AnySchema{}, UniqueItems: true},
+			value:  []interface{}{map[string]interface{}{"a": float64(1)}, map[string]interface{}{"a": float64(1)}},
+			want:   api.ValidationErrors{{Pointer: "", Message: "items 0 and 1 must be unique"}},
+		},
+		{
+			name: "nested object",
+			schema: api.ObjectSchema{
+				Properties: map[string]api.Schema{
+					"user": api.ObjectSchema{
+						Properties: map[string]api.Schema{
+							"name": api.StringSchema{},
+						},
+						Required:             []string{"name"},
+						AdditionalProperties: api.FalseSchema{},
+					},
+				},
+			},
+			value: map[string]interface{}{
+				"user": map[string]interface{}{
+					"age": float64(1),
+				},
+			},
+			want: api.ValidationErrors{
+				{Pointer: "/user/name", Message: "required property is missing"},
+				{Pointer: "/user/age", Message: "additional property is not allowed"},
+			},
+		},
+		{
+			name: "additional properties schema",
+			schema: api.ObjectSchema{
+				AdditionalProperties: api.IntSchema{},
+				MaxProperties:        uint64Ptr(1),
+			},
+			value: map[string]interface{}{
+				"a": "1",
+				"b": float64(2),
+			},
+			want: api.ValidationErrors{
+				{Pointer: "", Message: "must contain at most 1 properties"},
+				{Pointer: "/a", Message: "must be integer, got string"},
+			},
+		},
+		{
+			name:   "nullable",
+			schema: api.NullableSchema{Schema: api.StringSchema{}},
+			value:  nil,
+			want:   nil,
+		},
+		{
+			name:   "not nullable",
+			schema: api.StringSchema{},
+			value:  nil,
+			want:   api.ValidationErrors{{Pointer: "", Message: "must be string, got null"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.schema.Validate("", tc.value))
+		})
+	}
+}
+
+func TestIsFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+		want   bool
+	}{
+		{format: "date", value: "2021-12-31", want: true},
+		{format: "date", value: "2021-13-31", want: false},
+		{format: "date-time", value: "2021-12-31T23:59:59Z", want: true},
+		{format: "email", value: "elon@example.com", want: true},
+		{format: "email", value: "Elon <elon@example.com>", want: false},
+		{format: "uuid", value: "e1afccea-5168-4735-84d4-cb96f6fb5d25", want: true},
+		{format: "uri", value: "https://example.com", want: true},
+		{format: "uri", value: "example", want: false},
+		{format: "ipv4", value: "127.0.0.1", want: true},
+		{format: "ipv4", value: "::1", want: false},
+		{format: "ipv6", value: "::1", want: true},
+		{format: "hostname", value: "example.com", want: true},
+		{format: "byte", value: "ZHVtbXk=", want: true},
+		{format: "unknown", value: "anything", want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format+" "+tc.value, func(t *testing.T) {
+			require.Equal(t, tc.want, api.IsFormat(tc.format, tc.value))
+		})
+	}
+}
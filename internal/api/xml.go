@@ -0,0 +1,100 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+// XML describes XML representation of schema: name of element, namespace, prefix,
+// attribute instead of element and wrapping element of array
+type XML struct {
+	Name      string
+	Namespace string
+	Prefix    string
+	Attribute bool
+	Wrapped   bool
+}
+
+func convertXML(x *openapi.XML) *XML {
+	if nil == x {
+		return nil
+	}
+
+	return &XML{
+		Name:      x.Name,
+		Namespace: x.Namespace,
+		Prefix:    x.Prefix,
+		Attribute: x.Attribute,
+		Wrapped:   x.Wrapped,
+	}
+}
+
+// IsXMLMediaType returns true for application/xml, text/xml and media types with +xml suffix, e.g. application/atom+xml
+func IsXMLMediaType(mediaType string) bool {
+	typ, subtype := splitMediaType(mediaType)
+
+	return (typ == "application" || typ == "text") && subtype == "xml" || strings.HasSuffix(subtype, "+xml")
+}
+
+// XMLOf returns XML representation of schema, nil if it is not specified
+func XMLOf(s Schema) *XML {
+	switch s := s.(type) {
+	case BooleanSchema:
+		return s.XML
+	case IntSchema:
+		return s.XML
+	case FloatSchema:
+		return s.XML
+	case StringSchema:
+		return s.XML
+	case ArraySchema:
+		return s.XML
+	case ObjectSchema:
+		return s.XML
+	case NullableSchema:
+		return XMLOf(s.Schema)
+	}
+
+	return nil
+}
+
+// withXMLName returns object or array schema with name of XML element if schema has no name,
+// it is used for schemas referenced by $ref which are named by name of component
+func withXMLName(s Schema, ref string) Schema {
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	if name == "" {
+		return s
+	}
+
+	named := func(x *XML) *XML {
+		if x != nil && x.Name != "" {
+			return x
+		}
+
+		res := &XML{Name: name}
+		if x != nil {
+			*res = *x
+			res.Name = name
+		}
+
+		return res
+	}
+
+	switch schema := s.(type) {
+	case ObjectSchema:
+		schema.XML = named(schema.XML)
+
+		return schema
+	case ArraySchema:
+		schema.XML = named(schema.XML)
+
+		return schema
+	case NullableSchema:
+		schema.Schema = withXMLName(schema.Schema, ref)
+
+		return schema
+	}
+
+	return s
+}
@@ -1,12 +1,273 @@
 package config
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// DefaultPath is path of configuration file which is loaded if it exists
+	DefaultPath = "dummy.yml"
+	// EnvPrefix is prefix of environment variables which override configuration file
+	EnvPrefix = "DUMMY_"
+)
+
 // Config is struct for Config
 type Config struct {
-	Server Server
-	Logger Logger
+	Server Server `yaml:"server"`
+	Logger Logger `yaml:"logger"`
 }
 
-// NewConfig returns a new instance of Config instance
+// NewConfig returns a new instance of Config instance with default values
 func NewConfig() *Config {
-	return &Config{}
+	return &Config{
+		Server: Server{
+			Port:          "8080",
+			ExamplePolicy: "warn",
+			JournalLimit:  1000,
+		},
+		Logger: Logger{
+			Level: "INFO",
+		},
+	}
+}
+
+// KeyError -.
+type KeyError struct {
+	Key string
+}
+
+// Error -.
+func (e *KeyError) Error() string {
+	return "unknown configuration key " + e.Key
+}
+
+// ValueError -.
+type ValueError struct {
+	Key     string
+	Message string
+}
+
+// Error -.
+func (e *ValueError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// Load reads configuration file, keys which are not in Config are error
+func (c *Config) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	err = yaml.NewDecoder(bytes.NewReader(data), yaml.DisallowUnknownField()).Decode(c)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadEnv overrides configuration by DUMMY_* environment variables, e.g. DUMMY_SERVER_PORT for server.port
+func (c *Config) LoadEnv(environ []string) error {
+	keys := make(map[string]string)
+
+	for _, key := range Keys() {
+		keys[EnvName(key)] = key
+	}
+
+	for _, v := range environ {
+		if !strings.HasPrefix(v, EnvPrefix) {
+			continue
+		}
+
+		pair := strings.SplitN(v, "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+
+		key, ok := keys[pair[0]]
+		if !ok {
+			return &KeyError{Key: pair[0]}
+		}
+
+		if err := c.Set(key, pair[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnvName returns name of environment variable for configuration key
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// Keys returns all configuration keys, e.g. server.port
+func Keys() []string {
+	return keys("", reflect.TypeOf(Config{}))
+}
+
+func keys(prefix string, t reflect.Type) []string {
+	var res []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + name(field)
+
+		if field.Type.Kind() == reflect.Struct {
+			res = append(res, keys(key+".", field.Type)...)
+
+			continue
+		}
+
+		res = append(res, key)
+	}
+
+	return res
+}
+
+func name(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("yaml"), ",")[0]
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Set sets value of configuration key, lists are separated by comma
+func (c *Config) Set(key, value string) error {
+	field := reflect.ValueOf(c).Elem()
+
+	for _, part := range strings.Split(key, ".") {
+		if field.Kind() != reflect.Struct {
+			return &KeyError{Key: key}
+		}
+
+		found := false
+
+		for i := 0; i < field.NumField(); i++ {
+			if name(field.Type().Field(i)) == part {
+				field = field.Field(i)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return &KeyError{Key: key}
+		}
+	}
+
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return &ValueError{Key: key, Message: "must be duration, e.g. 100ms, got " + value}
+		}
+
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return &ValueError{Key: key, Message: "must be boolean, got " + value}
+		}
+
+		field.SetBool(b)
+	case field.Kind() == reflect.Int64 || field.Kind() == reflect.Int:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return &ValueError{Key: key, Message: "must be integer, got " + value}
+		}
+
+		field.SetInt(n)
+	case field.Kind() == reflect.Slice:
+		var list []string
+
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				list = append(list, v)
+			}
+		}
+
+		field.Set(reflect.ValueOf(list))
+	default:
+		return &KeyError{Key: key}
+	}
+
+	return nil
+}
+
+// Validate returns error if configuration values are invalid
+func (c *Config) Validate() error {
+	if !validPort(c.Server.Port) {
+		return &ValueError{Key: "server.port", Message: "must be number between 1 and 65535, got " + c.Server.Port}
+	}
+
+	for _, m := range c.Server.Mounts() {
+		if m.Port != "" && !validPort(m.Port) {
+			return &ValueError{Key: "server.specs", Message: "port of " + m.Path + " must be number between 1 and 65535, got " + m.Port}
+		}
+	}
+
+	if c.Server.BasePath != "" && !strings.HasPrefix(c.Server.BasePath, "/") {
+		return &ValueError{Key: "server.base-path", Message: "must start with /, got " + c.Server.BasePath}
+	}
+
+	switch strings.ToUpper(c.Logger.Level) {
+	case "DEBUG", "INFO", "WARN", "ERROR":
+	default:
+		return &ValueError{Key: "logger.level", Message: "must be one of DEBUG, INFO, WARN, ERROR, got " + c.Logger.Level}
+	}
+
+	if c.Server.Latency < 0 {
+		return &ValueError{Key: "server.latency", Message: "must not be negative"}
+	}
+
+	switch strings.ToLower(c.Server.ExamplePolicy) {
+	case "warn", "fail", "fallback":
+	default:
+		return &ValueError{Key: "server.example-policy", Message: "must be one of warn, fail, fallback, got " + c.Server.ExamplePolicy}
+	}
+
+	if c.Server.Upstream != "" {
+		u, err := url.Parse(c.Server.Upstream)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return &ValueError{Key: "server.upstream", Message: "must be absolute URL, got " + c.Server.Upstream}
+		}
+	}
+
+	if c.Server.JournalLimit < 0 {
+		return &ValueError{Key: "server.journal-limit", Message: "must not be negative"}
+	}
+
+	if c.Server.WatchInterval < 0 {
+		return &ValueError{Key: "server.watch-interval", Message: "must not be negative"}
+	}
+
+	if (c.Server.TLS.Cert == "") != (c.Server.TLS.Key == "") {
+		return &ValueError{Key: "server.tls", Message: "cert and key must be set together"}
+	}
+
+	return nil
+}
+
+func validPort(value string) bool {
+	const maxPort = 65535
+
+	port, err := strconv.Atoi(value)
+
+	return err == nil && port >= 1 && port <= maxPort
 }
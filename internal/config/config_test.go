@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -13,3 +14,215 @@ func TestNewConfig(t *testing.T) {
 
 	require.IsType(t, &config.Config{}, conf)
 }
+
+func TestConfig_Load(t *testing.T) {
+	conf := config.NewConfig()
+
+	require.NoError(t, conf.Load("testdata/dummy.yml"))
+	require.Equal(t, &config.Config{
+		Server: config.Server{
+			Path:          "openapi.yml",
+			Host:          "127.0.0.1",
+			Port:          "9090",
+			Stateful:      true,
+			Latency:       100 * time.Millisecond,
+			JournalLimit:  50,
+			ExamplePolicy: "fallback",
+			CORS: config.CORS{
+				Enabled:      true,
+				AllowOrigins: []string{"https://example.com"},
+			},
+			TLS: config.TLS{
+				Cert: "cert.pem",
+				Key:  "key.pem",
+			},
+		},
+		Logger: config.Logger{
+			Level: "DEBUG",
+		},
+	}, conf)
+	require.NoError(t, conf.Validate())
+	require.Equal(t, "127.0.0.1:9090", conf.Server.Addr())
+}
+
+func TestConfig_Load_Empty(t *testing.T) {
+	conf := config.NewConfig()
+
+	require.NoError(t, conf.Load("testdata/empty.yml"))
+	require.Equal(t, config.NewConfig(), conf)
+}
+
+func TestConfig_Load_UnknownKey(t *testing.T) {
+	conf := config.NewConfig()
+
+	err := conf.Load("testdata/unknown.yml")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown field "prot"`)
+}
+
+func TestConfig_LoadEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ []string
+		want    func(c *config.Config)
+		err     string
+	}{
+		{
+			name:    "override",
+			environ: []string{"HOME=/root", "DUMMY_SERVER_PORT=9000", "DUMMY_SERVER_WATCH_INTERVAL=5s", "DUMMY_SERVER_CORS_ALLOW_ORIGINS=https://a.com, https://b.com"},
+			want: func(c *config.Config) {
+				c.Server.Port = "9000"
+				c.Server.WatchInterval = 5 * time.Second
+				c.Server.CORS.AllowOrigins = []string{"https://a.com", "https://b.com"}
+			},
+		},
+		{
+			name:    "unknown variable",
+			environ: []string{"DUMMY_SERVER_PROT=9000"},
+			err:     "unknown configuration key DUMMY_SERVER_PROT",
+		},
+		{
+			name:    "wrong value",
+			environ: []string{"DUMMY_SERVER_STATEFUL=yes"},
+			err:     "server.stateful: must be boolean, got yes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := config.NewConfig()
+
+			err := conf.LoadEnv(tc.environ)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			want := config.NewConfig()
+			tc.want(want)
+
+			require.NoError(t, err)
+			require.Equal(t, want, conf)
+		})
+	}
+}
+
+func TestConfig_Set(t *testing.T) {
+	conf := config.NewConfig()
+
+	require.NoError(t, conf.Set("server.seed", "42"))
+	require.Equal(t, int64(42), conf.Server.Seed)
+	require.EqualError(t, conf.Set("server.latency", "1"), "server.latency: must be duration, e.g. 100ms, got 1")
+	require.EqualError(t, conf.Set("server.tls", "1"), "unknown configuration key server.tls")
+	require.EqualError(t, conf.Set("server.port.number", "1"), "unknown configuration key server.port.number")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(c *config.Config)
+		err  string
+	}{
+		{
+			name: "default",
+			set:  func(c *config.Config) {},
+		},
+		{
+			name: "wrong port",
+			set:  func(c *config.Config) { c.Server.Port = "http" },
+			err:  "server.port: must be number between 1 and 65535, got http",
+		},
+		{
+			name: "wrong logger level",
+			set:  func(c *config.Config) { c.Logger.Level = "TRACE" },
+			err:  "logger.level: must be one of DEBUG, INFO, WARN, ERROR, got TRACE",
+		},
+		{
+			name: "negative latency",
+			set:  func(c *config.Config) { c.Server.Latency = -time.Second },
+			err:  "server.latency: must not be negative",
+		},
+		{
+			name: "relative upstream",
+			set:  func(c *config.Config) { c.Server.Upstream = "localhost:9000" },
+			err:  "server.upstream: must be absolute URL, got localhost:9000",
+		},
+		{
+			name: "negative journal limit",
+			set:  func(c *config.Config) { c.Server.JournalLimit = -1 },
+			err:  "server.journal-limit: must not be negative",
+		},
+		{
+			name: "wrong example policy",
+			set:  func(c *config.Config) { c.Server.ExamplePolicy = "ignore" },
+			err:  "server.example-policy: must be one of warn, fail, fallback, got ignore",
+		},
+		{
+			name: "wrong port of specification",
+			set:  func(c *config.Config) { c.Server.Specs = []string{":http/orders=orders.yml"} },
+			err:  "server.specs: port of orders.yml must be number between 1 and 65535, got http",
+		},
+		{
+			name: "relative base path",
+			set:  func(c *config.Config) { c.Server.BasePath = "v2" },
+			err:  "server.base-path: must start with /, got v2",
+		},
+		{
+			name: "tls without key",
+			set:  func(c *config.Config) { c.Server.TLS.Cert = "cert.pem" },
+			err:  "server.tls: cert and key must be set together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := config.NewConfig()
+			tc.set(conf)
+
+			err := conf.Validate()
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseMount(t *testing.T) {
+	tests := []struct {
+		value string
+		want  config.Mount
+	}{
+		{value: "openapi.yml", want: config.Mount{Path: "openapi.yml"}},
+		{value: "https://example.com/openapi.yml?token=a=b", want: config.Mount{Path: "https://example.com/openapi.yml?token=a=b"}},
+		{value: "/orders=orders.yml", want: config.Mount{Path: "orders.yml", Prefix: "/orders"}},
+		{value: ":8081=orders.yml", want: config.Mount{Path: "orders.yml", Port: "8081"}},
+		{value: ":8081/v2=https://example.com/openapi.yml", want: config.Mount{Path: "https://example.com/openapi.yml", Port: "8081", Prefix: "/v2"}},
+		{value: "/=openapi.yml", want: config.Mount{Path: "openapi.yml", Prefix: "/"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			require.Equal(t, tc.want, config.ParseMount(tc.value))
+		})
+	}
+}
+
+func TestServer_Mounts(t *testing.T) {
+	s := config.Server{Path: "users.yml", Specs: []string{"/orders=orders.yml"}}
+
+	require.Equal(t, []config.Mount{
+		{Path: "users.yml"},
+		{Path: "orders.yml", Prefix: "/orders"},
+	}, s.Mounts())
+}
+
+func TestEnvName(t *testing.T) {
+	require.Equal(t, "DUMMY_SERVER_WATCH_INTERVAL", config.EnvName("server.watch-interval"))
+	require.Contains(t, config.Keys(), "server.cors.allow-headers")
+}
@@ -2,5 +2,5 @@ package config
 
 // Logger is struct for Logger
 type Logger struct {
-	Level string
+	Level string `yaml:"level"`
 }
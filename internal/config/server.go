@@ -1,8 +1,106 @@
 package config
 
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
 // Server is struct for Server
 type Server struct {
-	// Path to OpenAPI specification
+	// Path to OpenAPI specification, it may be mounted like Specs
+	Path string `yaml:"path"`
+	// Specs are paths to additional specifications, specification is mounted at prefix or port by [:port][/prefix]=path
+	Specs []string `yaml:"specs"`
+	// BasePath overrides base path of servers of specifications which are not mounted at prefix, / serves them at root
+	BasePath string `yaml:"base-path"`
+	// Host is listen address, empty host means all interfaces
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+	// Stateful enables in-memory resource store for CRUD operations
+	Stateful bool `yaml:"stateful"`
+	// Seed makes generated response values reproducible, zero means random seed
+	Seed int64 `yaml:"seed"`
+	// Watch enables reload of specification on change
+	Watch bool `yaml:"watch"`
+	// WatchInterval is polling interval of specification, zero means default interval
+	WatchInterval time.Duration `yaml:"watch-interval"`
+	// Latency is delay of every response
+	Latency time.Duration `yaml:"latency"`
+	// Upstream is URL of backend which serves operations which are not specified or are marked by x-dummy-proxy
+	Upstream string `yaml:"upstream"`
+	// Replay is directory of recordings which are served before responses of specification
+	Replay string `yaml:"replay"`
+	// JournalLimit is number of requests which are kept for admin endpoint, zero disables journal
+	JournalLimit int `yaml:"journal-limit"`
+	// ExamplePolicy is policy for response examples which do not match their schemas: warn, fail or fallback
+	ExamplePolicy string `yaml:"example-policy"`
+	CORS          CORS   `yaml:"cors"`
+	TLS           TLS    `yaml:"tls"`
+}
+
+// Addr returns listen address of server
+func (s Server) Addr() string {
+	return s.Host + ":" + s.Port
+}
+
+// Mount is specification which is served at prefix of port
+type Mount struct {
+	// Path is path or URL of specification
 	Path string
+	// Port is listen port, empty port is port of server
 	Port string
+	// Prefix is base path of operations, empty prefix is base path of specification
+	Prefix string
+}
+
+var mount = regexp.MustCompile(`^(:[^/=]*)?(/[^=]*)?=(.+)$`)
+
+// ParseMount returns mount of specification, e.g. :8081/orders=orders.yml, value without port and prefix is path
+func ParseMount(value string) Mount {
+	m := mount.FindStringSubmatch(value)
+	if nil == m || m[1]+m[2] == "" {
+		return Mount{Path: value}
+	}
+
+	return Mount{
+		Path:   m[3],
+		Port:   strings.TrimPrefix(m[1], ":"),
+		Prefix: m[2],
+	}
+}
+
+// Mounts returns mounts of Path and Specs
+func (s Server) Mounts() []Mount {
+	var mounts []Mount
+
+	for _, spec := range append([]string{s.Path}, s.Specs...) {
+		if spec != "" {
+			mounts = append(mounts, ParseMount(spec))
+		}
+	}
+
+	return mounts
+}
+
+// CORS is struct for CORS
+type CORS struct {
+	Enabled bool `yaml:"enabled"`
+	// AllowOrigins is list of allowed origins, empty list allows any origin
+	AllowOrigins []string `yaml:"allow-origins"`
+	// AllowMethods is list of allowed methods, empty list allows any method
+	AllowMethods []string `yaml:"allow-methods"`
+	// AllowHeaders is list of allowed headers, empty list allows headers requested by preflight request
+	AllowHeaders []string `yaml:"allow-headers"`
+}
+
+// TLS is struct for TLS, server uses HTTPS if certificate and key are set
+type TLS struct {
+	Cert string `yaml:"cert"`
+	Key  string `yaml:"key"`
+}
+
+// Enabled returns true if certificate and key are set
+func (t TLS) Enabled() bool {
+	return t.Cert != "" && t.Key != ""
 }
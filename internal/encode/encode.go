@@ -0,0 +1,412 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+// Media types which have own serializers, JSON and XML are detected by api.IsJSONMediaType and api.IsXMLMediaType
+const (
+	TextPlain = "text/plain"
+	TextCSV   = "text/csv"
+	NDJSON    = "application/x-ndjson"
+)
+
+// Encode writes value in format of media type, schema describes XML representation of value
+// JSON is written for empty media type, values of other media types are written as text
+func Encode(w io.Writer, mediaType string, schema api.Schema, value interface{}) error {
+	base := strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+
+	if base == "" || api.IsJSONMediaType(base) {
+		return encodeJSON(w, value)
+	}
+
+	value, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case api.IsXMLMediaType(base):
+		return encodeXML(w, schema, value)
+	case base == TextCSV:
+		return encodeCSV(w, schema, value)
+	case base == NDJSON:
+		return encodeNDJSON(w, value)
+	default:
+		_, err := io.WriteString(w, text(value))
+
+		return err
+	}
+}
+
+// normalize returns value with objects and arrays of JSON decoding, e.g. []map[string]interface{} is []interface{}
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var res interface{}
+
+	if err := d.Decode(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func encodeJSON(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// encodeNDJSON writes every item of array as JSON on its own line, other values are written as single line
+func encodeNDJSON(w io.Writer, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+
+	for _, item := range items {
+		if err := encodeJSON(w, item); err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// text returns scalar value as is and JSON of objects and arrays
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int, int32, int64, uint, uint32, uint64:
+		return fmt.Sprint(v)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// encodeCSV writes array of objects as rows with header of property names, object as one row and scalars as one column
+func encodeCSV(w io.Writer, schema api.Schema, value interface{}) error {
+	rows, ok := value.([]interface{})
+	if !ok {
+		rows = []interface{}{value}
+	}
+
+	columns := csvColumns(schema, rows)
+
+	cw := csv.NewWriter(w)
+
+	if len(columns) > 0 {
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		obj, ok := row.(map[string]interface{})
+		if !ok || len(columns) == 0 {
+			if err := cw.Write([]string{text(row)}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			record = append(record, text(obj[column]))
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// csvColumns returns sorted properties of object schema or keys of objects if schema has no properties
+func csvColumns(schema api.Schema, rows []interface{}) []string {
+	if arr, ok := unwrap(schema).(api.ArraySchema); ok {
+		schema = arr.Type
+	}
+
+	set := make(map[string]struct{})
+
+	for key := range properties(schema) {
+		set[key] = struct{}{}
+	}
+
+	if len(set) == 0 {
+		for _, row := range rows {
+			obj, ok := row.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for key := range obj {
+				set[key] = struct{}{}
+			}
+		}
+	}
+
+	return sortedKeys(set)
+}
+
+// encodeXML writes value as XML document according to xml objects of schema
+// Root element is named by xml name of schema or "root", items of root array are named by xml name of items or "item"
+func encodeXML(w io.Writer, schema api.Schema, value interface{}) error {
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+
+	name := "root"
+	if x := api.XMLOf(schema); x != nil && x.Name != "" {
+		name = x.Name
+	}
+
+	if items, ok := value.([]interface{}); ok {
+		itemsSchema := itemsOf(schema)
+
+		itemName := "item"
+		if x := api.XMLOf(itemsSchema); x != nil && x.Name != "" {
+			itemName = x.Name
+		}
+
+		buf.WriteString("<" + qualified(api.XMLOf(schema), name) + namespace(api.XMLOf(schema)) + ">")
+
+		for _, item := range items {
+			writeElement(&buf, itemName, itemsSchema, item)
+		}
+
+		buf.WriteString("</" + qualified(api.XMLOf(schema), name) + ">")
+	} else {
+		writeElement(&buf, name, schema, value)
+	}
+
+	buf.WriteString("\n")
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// writeElement writes value as element, properties of objects with attribute in xml object are written as attributes
+func writeElement(buf *bytes.Buffer, name string, schema api.Schema, value interface{}) {
+	x := api.XMLOf(schema)
+	tag := qualified(x, name)
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		if items, ok := value.([]interface{}); ok {
+			// array without property name is written as wrapped array
+			buf.WriteString("<" + tag + namespace(x) + ">")
+
+			for _, item := range items {
+				writeElement(buf, name, itemsOf(schema), item)
+			}
+
+			buf.WriteString("</" + tag + ">")
+
+			return
+		}
+
+		buf.WriteString("<" + tag + namespace(x) + ">")
+		escape(buf, text(value))
+		buf.WriteString("</" + tag + ">")
+
+		return
+	}
+
+	props := properties(schema)
+	keys := make([]string, 0, len(obj))
+
+	for key := range obj {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	buf.WriteString("<" + tag + namespace(x))
+
+	for _, key := range keys {
+		px := api.XMLOf(props[key])
+		if nil == px || !px.Attribute {
+			continue
+		}
+
+		buf.WriteString(" " + qualified(px, key) + `="`)
+		escape(buf, text(obj[key]))
+		buf.WriteString(`"`)
+	}
+
+	buf.WriteString(">")
+
+	for _, key := range keys {
+		if px := api.XMLOf(props[key]); px != nil && px.Attribute {
+			continue
+		}
+
+		writeProperty(buf, key, props[key], obj[key])
+	}
+
+	buf.WriteString("</" + tag + ">")
+}
+
+// writeProperty writes property of object, items of array are repeated elements named by xml name of items or
+// name of property, wrapped array is written inside element named by property
+func writeProperty(buf *bytes.Buffer, key string, schema api.Schema, value interface{}) {
+	items, ok := value.([]interface{})
+	if !ok {
+		writeElement(buf, key, schema, value)
+
+		return
+	}
+
+	x := api.XMLOf(schema)
+	itemsSchema := itemsOf(schema)
+
+	name := key
+	if x != nil && x.Name != "" {
+		name = x.Name
+	}
+
+	itemName := name
+	if ix := api.XMLOf(itemsSchema); ix != nil && ix.Name != "" {
+		itemName = ix.Name
+	}
+
+	wrapped := x != nil && x.Wrapped
+	if wrapped {
+		buf.WriteString("<" + qualified(x, name) + namespace(x) + ">")
+	}
+
+	for _, item := range items {
+		writeElement(buf, itemName, itemsSchema, item)
+	}
+
+	if wrapped {
+		buf.WriteString("</" + qualified(x, name) + ">")
+	}
+}
+
+// qualified returns name of xml object or default name with prefix of xml object
+func qualified(x *api.XML, name string) string {
+	if nil == x {
+		return name
+	}
+
+	if x.Name != "" {
+		name = x.Name
+	}
+
+	if x.Prefix != "" {
+		return x.Prefix + ":" + name
+	}
+
+	return name
+}
+
+// namespace returns namespace declaration of xml object
+func namespace(x *api.XML) string {
+	if nil == x || x.Namespace == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+
+	if x.Prefix != "" {
+		buf.WriteString(" xmlns:" + x.Prefix + `="`)
+	} else {
+		buf.WriteString(` xmlns="`)
+	}
+
+	escape(&buf, x.Namespace)
+	buf.WriteString(`"`)
+
+	return buf.String()
+}
+
+func escape(buf *bytes.Buffer, s string) {
+	// writing to buffer does not fail
+	_ = xml.EscapeText(buf, []byte(s))
+}
+
+func unwrap(schema api.Schema) api.Schema {
+	if n, ok := schema.(api.NullableSchema); ok {
+		return n.Schema
+	}
+
+	return schema
+}
+
+func properties(schema api.Schema) map[string]api.Schema {
+	if obj, ok := unwrap(schema).(api.ObjectSchema); ok {
+		return obj.Properties
+	}
+
+	return nil
+}
+
+func itemsOf(schema api.Schema) api.Schema {
+	if arr, ok := unwrap(schema).(api.ArraySchema); ok {
+		return arr.Type
+	}
+
+	return nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
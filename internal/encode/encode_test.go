@@ -0,0 +1,135 @@
+package encode_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/encode"
+)
+
+func TestEncode(t *testing.T) {
+	user := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"id":   api.IntSchema{XML: &api.XML{Attribute: true}},
+			"name": api.StringSchema{XML: &api.XML{Name: "fullName"}},
+			"tags": api.ArraySchema{Type: api.StringSchema{XML: &api.XML{Name: "tag"}}, XML: &api.XML{Wrapped: true}},
+			"pets": api.ArraySchema{Type: api.StringSchema{}},
+		},
+		XML: &api.XML{Name: "user", Prefix: "u", Namespace: "https://example.com/user"},
+	}
+	users := api.ArraySchema{Type: user, XML: &api.XML{Name: "users"}}
+
+	value := map[string]interface{}{
+		"id":   1,
+		"name": "Elon & Co",
+		"tags": []interface{}{"ceo", "founder"},
+		"pets": []interface{}{"Marvin", "Floki"},
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		schema    api.Schema
+		value     interface{}
+		want      string
+	}{
+		{
+			name:  "JSON by default",
+			value: map[string]interface{}{"a": 1},
+			want:  `{"a":1}`,
+		},
+		{
+			name:      "JSON suffix",
+			mediaType: "application/problem+json; charset=utf-8",
+			value:     []interface{}{1, "a"},
+			want:      `[1,"a"]`,
+		},
+		{
+			name:      "XML object",
+			mediaType: "application/xml",
+			schema:    user,
+			value:     value,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<u:user xmlns:u="https://example.com/user" id="1"><fullName>Elon &amp; Co</fullName><pets>Marvin</pets><pets>Floki</pets><tags><tag>ceo</tag><tag>founder</tag></tags></u:user>
+`,
+		},
+		{
+			name:      "XML array",
+			mediaType: "text/xml",
+			schema:    users,
+			value:     []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 2}},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<users><u:user xmlns:u="https://example.com/user" id="1"></u:user><u:user xmlns:u="https://example.com/user" id="2"></u:user></users>
+`,
+		},
+		{
+			name:      "XML without schema",
+			mediaType: "application/xml",
+			value:     []interface{}{"a", true},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<root><item>a</item><item>true</item></root>
+`,
+		},
+		{
+			name:      "text",
+			mediaType: "text/plain",
+			value:     "pong",
+			want:      "pong",
+		},
+		{
+			name:      "text number",
+			mediaType: "text/plain",
+			value:     1.5,
+			want:      "1.5",
+		},
+		{
+			name:      "text object",
+			mediaType: "text/plain",
+			value:     map[string]interface{}{"a": true},
+			want:      `{"a":true}`,
+		},
+		{
+			name:      "CSV array of objects",
+			mediaType: "text/csv",
+			schema:    users,
+			value:     []interface{}{value, map[string]interface{}{"id": 2, "name": "Sergey"}},
+			want:      "id,name,pets,tags\n1,Elon & Co,\"[\"\"Marvin\"\",\"\"Floki\"\"]\",\"[\"\"ceo\"\",\"\"founder\"\"]\"\n2,Sergey,,\n",
+		},
+		{
+			name:      "CSV without schema",
+			mediaType: "text/csv",
+			value:     map[string]interface{}{"b": 2, "a": "x,y"},
+			want:      "a,b\n\"x,y\",2\n",
+		},
+		{
+			name:      "CSV scalars",
+			mediaType: "text/csv",
+			value:     []interface{}{"a", "b"},
+			want:      "a\nb\n",
+		},
+		{
+			name:      "NDJSON",
+			mediaType: "application/x-ndjson",
+			value:     []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 2}},
+			want:      "{\"id\":1}\n{\"id\":2}\n",
+		},
+		{
+			name:      "NDJSON object",
+			mediaType: "application/x-ndjson",
+			value:     map[string]interface{}{"id": 1},
+			want:      "{\"id\":1}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			require.NoError(t, encode.Encode(&buf, tc.mediaType, tc.schema, tc.value))
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
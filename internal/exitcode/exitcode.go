@@ -1,8 +1,55 @@
 package exitcode
 
+import (
+	"errors"
+)
+
 const (
 	// Success -.
 	Success = iota
-	// Failure -.
+	// Failure is runtime failure, e.g. server can not listen port
 	Failure
+	// Usage is wrong command line arguments or configuration
+	Usage
+	// Spec is invalid or unavailable specification
+	Spec
 )
+
+// Error is error with exit code of program
+type Error struct {
+	Code int
+	Err  error
+}
+
+// Error -.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap -.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap returns error with exit code, nil error is not wrapped
+func Wrap(code int, err error) error {
+	if nil == err {
+		return nil
+	}
+
+	return &Error{Code: code, Err: err}
+}
+
+// Code returns exit code of error, errors without code are failures
+func Code(err error) int {
+	if nil == err {
+		return Success
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	return Failure
+}
@@ -0,0 +1,533 @@
+package graphql
+
+import (
+	"errors"
+	"sync"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/neotoolkit/faker"
+)
+
+// ErrEmptyQueryType -.
+var ErrEmptyQueryType = errors.New("schema without query type")
+
+// TypeError -.
+type TypeError struct {
+	Name string
+}
+
+// Error -.
+func (e *TypeError) Error() string {
+	return "unknown type " + e.Name
+}
+
+// Schema is executable GraphQL schema which resolves every field with generated value
+type Schema struct {
+	schema gql.Schema
+
+	mu    sync.Mutex
+	faker faker.Faker
+}
+
+// Params -.
+type Params struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Parse returns a new instance of Schema from GraphQL SDL
+func Parse(data []byte, f faker.Faker) (*Schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: string(data),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{
+		faker: f,
+	}
+
+	b := newBuilder(s.resolve)
+
+	schema, err := b.build(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	s.schema = schema
+
+	return s, nil
+}
+
+// Do executes GraphQL query against schema
+func (s *Schema) Do(params Params) *gql.Result {
+	return gql.Do(gql.Params{
+		Schema:         s.schema,
+		RequestString:  params.Query,
+		OperationName:  params.OperationName,
+		VariableValues: params.Variables,
+	})
+}
+
+func (s *Schema) resolve(p gql.ResolveParams) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.value(p.Info.ReturnType, p.Info.FieldName), nil
+}
+
+// listLength is number of items generated for list fields
+const listLength = 2
+
+func (s *Schema) value(t gql.Output, name string) interface{} {
+	switch t := t.(type) {
+	case *gql.NonNull:
+		return s.value(t.OfType, name)
+	case *gql.List:
+		list := make([]interface{}, listLength)
+
+		for i := range list {
+			list[i] = s.value(t.OfType, name)
+		}
+
+		return list
+	case *gql.Enum:
+		values := t.Values()
+		if len(values) == 0 {
+			return nil
+		}
+
+		return values[s.faker.IntBetween(0, len(values)-1)].Value
+	case *gql.Object, *gql.Interface, *gql.Union:
+		return map[string]interface{}{}
+	case *gql.Scalar:
+		return s.scalar(t.Name(), name)
+	}
+
+	return nil
+}
+
+func (s *Schema) scalar(typeName, fieldName string) interface{} {
+	switch typeName {
+	case gql.ID.Name():
+		return s.faker.UUID().V4()
+	case gql.Int.Name():
+		return s.faker.IntBetween(0, 100)
+	case gql.Float.Name():
+		return float64(s.faker.IntBetween(0, 10000)) / 100
+	case gql.Boolean.Name():
+		return s.faker.Boolean().Boolean()
+	}
+
+	if v, ok := s.faker.ByName(fieldName).(string); ok {
+		return v
+	}
+
+	return s.faker.Asciify("**********")
+}
+
+type builder struct {
+	resolve gql.FieldResolveFn
+
+	defs       map[string]ast.Node
+	order      []string
+	extensions map[string][]*ast.FieldDefinition
+	types      map[string]gql.Type
+	// objects are in order of SDL, so interface type is resolved the same way on every run
+	objects []*gql.Object
+}
+
+func newBuilder(resolve gql.FieldResolveFn) *builder {
+	return &builder{
+		resolve:    resolve,
+		defs:       make(map[string]ast.Node),
+		extensions: make(map[string][]*ast.FieldDefinition),
+		types: map[string]gql.Type{
+			gql.ID.Name():      gql.ID,
+			gql.Int.Name():     gql.Int,
+			gql.Float.Name():   gql.Float,
+			gql.String.Name():  gql.String,
+			gql.Boolean.Name(): gql.Boolean,
+		},
+	}
+}
+
+func (b *builder) build(doc *ast.Document) (gql.Schema, error) {
+	operations := map[string]string{
+		ast.OperationTypeQuery:        "Query",
+		ast.OperationTypeMutation:     "Mutation",
+		ast.OperationTypeSubscription: "Subscription",
+	}
+
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			for _, op := range def.OperationTypes {
+				operations[op.Operation] = op.Type.Name.Value
+			}
+		case *ast.TypeExtensionDefinition:
+			name := def.Definition.Name.Value
+			b.extensions[name] = append(b.extensions[name], def.Definition.Fields...)
+		case *ast.ScalarDefinition:
+			b.define(def.Name.Value, def)
+		case *ast.EnumDefinition:
+			b.define(def.Name.Value, def)
+		case *ast.ObjectDefinition:
+			b.define(def.Name.Value, def)
+		case *ast.InterfaceDefinition:
+			b.define(def.Name.Value, def)
+		case *ast.UnionDefinition:
+			b.define(def.Name.Value, def)
+		case *ast.InputObjectDefinition:
+			b.define(def.Name.Value, def)
+		}
+	}
+
+	for _, name := range b.order {
+		if _, err := b.named(name); err != nil {
+			return gql.Schema{}, err
+		}
+	}
+
+	if err := b.check(); err != nil {
+		return gql.Schema{}, err
+	}
+
+	query, ok := b.types[operations[ast.OperationTypeQuery]].(*gql.Object)
+	if !ok {
+		return gql.Schema{}, ErrEmptyQueryType
+	}
+
+	types := make([]gql.Type, 0, len(b.types))
+	for _, t := range b.types {
+		types = append(types, t)
+	}
+
+	config := gql.SchemaConfig{
+		Query: query,
+		Types: types,
+	}
+
+	if mutation, ok := b.types[operations[ast.OperationTypeMutation]].(*gql.Object); ok {
+		config.Mutation = mutation
+	}
+
+	if subscription, ok := b.types[operations[ast.OperationTypeSubscription]].(*gql.Object); ok {
+		config.Subscription = subscription
+	}
+
+	return gql.NewSchema(config)
+}
+
+// define adds type definition, the last definition of name wins
+func (b *builder) define(name string, def ast.Node) {
+	if _, ok := b.defs[name]; !ok {
+		b.order = append(b.order, name)
+	}
+
+	b.defs[name] = def
+}
+
+func (b *builder) named(name string) (gql.Type, error) {
+	if t, ok := b.types[name]; ok {
+		return t, nil
+	}
+
+	def, ok := b.defs[name]
+	if !ok {
+		return nil, &TypeError{Name: name}
+	}
+
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		b.types[name] = b.scalar(def)
+	case *ast.EnumDefinition:
+		b.types[name] = b.enum(def)
+	case *ast.ObjectDefinition:
+		obj := b.object(def)
+		b.types[name] = obj
+		b.objects = append(b.objects, obj)
+	case *ast.InterfaceDefinition:
+		b.types[name] = b.iface(def)
+	case *ast.UnionDefinition:
+		b.types[name] = b.union(def)
+	case *ast.InputObjectDefinition:
+		b.types[name] = b.input(def)
+	default:
+		return nil, &TypeError{Name: name}
+	}
+
+	return b.types[name], nil
+}
+
+// check returns error if any field or argument refers to undefined type
+func (b *builder) check() error {
+	var refs []ast.Type
+
+	fields := func(defs []*ast.FieldDefinition) {
+		for _, f := range defs {
+			refs = append(refs, f.Type)
+
+			for _, a := range f.Arguments {
+				refs = append(refs, a.Type)
+			}
+		}
+	}
+
+	for _, def := range b.defs {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			fields(def.Fields)
+
+			for _, i := range def.Interfaces {
+				refs = append(refs, i)
+			}
+		case *ast.InterfaceDefinition:
+			fields(def.Fields)
+		case *ast.UnionDefinition:
+			for _, t := range def.Types {
+				refs = append(refs, t)
+			}
+		case *ast.InputObjectDefinition:
+			for _, f := range def.Fields {
+				refs = append(refs, f.Type)
+			}
+		}
+	}
+
+	for _, ext := range b.extensions {
+		fields(ext)
+	}
+
+	for _, ref := range refs {
+		if _, err := b.named(baseName(ref)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func baseName(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return baseName(t.Type)
+	case *ast.List:
+		return baseName(t.Type)
+	case *ast.Named:
+		return t.Name.Value
+	}
+
+	return ""
+}
+
+func (b *builder) scalar(def *ast.ScalarDefinition) *gql.Scalar {
+	identity := func(value interface{}) interface{} {
+		return value
+	}
+
+	return gql.NewScalar(gql.ScalarConfig{
+		Name:        def.Name.Value,
+		Description: description(def.Description),
+		Serialize:   identity,
+		ParseValue:  identity,
+		ParseLiteral: func(value ast.Value) interface{} {
+			return value.GetValue()
+		},
+	})
+}
+
+func (b *builder) enum(def *ast.EnumDefinition) *gql.Enum {
+	values := make(gql.EnumValueConfigMap, len(def.Values))
+
+	for _, v := range def.Values {
+		values[v.Name.Value] = &gql.EnumValueConfig{
+			Value:       v.Name.Value,
+			Description: description(v.Description),
+		}
+	}
+
+	return gql.NewEnum(gql.EnumConfig{
+		Name:        def.Name.Value,
+		Description: description(def.Description),
+		Values:      values,
+	})
+}
+
+func (b *builder) object(def *ast.ObjectDefinition) *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name:        def.Name.Value,
+		Description: description(def.Description),
+		Interfaces: gql.InterfacesThunk(func() []*gql.Interface {
+			interfaces := make([]*gql.Interface, 0, len(def.Interfaces))
+
+			for _, named := range def.Interfaces {
+				if i, ok := b.types[named.Name.Value].(*gql.Interface); ok {
+					interfaces = append(interfaces, i)
+				}
+			}
+
+			return interfaces
+		}),
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return b.fields(append(def.Fields, b.extensions[def.Name.Value]...))
+		}),
+	})
+}
+
+func (b *builder) iface(def *ast.InterfaceDefinition) *gql.Interface {
+	name := def.Name.Value
+
+	return gql.NewInterface(gql.InterfaceConfig{
+		Name:        name,
+		Description: description(def.Description),
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return b.fields(def.Fields)
+		}),
+		// the first implementation in order of SDL is resolved
+		ResolveType: func(p gql.ResolveTypeParams) *gql.Object {
+			for _, obj := range b.objects {
+				for _, i := range obj.Interfaces() {
+					if i.Name() == name {
+						return obj
+					}
+				}
+			}
+
+			return nil
+		},
+	})
+}
+
+func (b *builder) union(def *ast.UnionDefinition) *gql.Union {
+	types := func() []*gql.Object {
+		objects := make([]*gql.Object, 0, len(def.Types))
+
+		for _, named := range def.Types {
+			if obj, ok := b.types[named.Name.Value].(*gql.Object); ok {
+				objects = append(objects, obj)
+			}
+		}
+
+		return objects
+	}
+
+	return gql.NewUnion(gql.UnionConfig{
+		Name:        def.Name.Value,
+		Description: description(def.Description),
+		Types:       gql.UnionTypesThunk(types),
+		ResolveType: func(p gql.ResolveTypeParams) *gql.Object {
+			objects := types()
+			if len(objects) == 0 {
+				return nil
+			}
+
+			return objects[0]
+		},
+	})
+}
+
+func (b *builder) input(def *ast.InputObjectDefinition) *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:        def.Name.Value,
+		Description: description(def.Description),
+		Fields: gql.InputObjectConfigFieldMapThunk(func() gql.InputObjectConfigFieldMap {
+			fields := make(gql.InputObjectConfigFieldMap, len(def.Fields))
+
+			for _, f := range def.Fields {
+				fields[f.Name.Value] = &gql.InputObjectFieldConfig{
+					Type:         b.typeOf(f.Type),
+					Description:  description(f.Description),
+					DefaultValue: defaultValue(f.DefaultValue),
+				}
+			}
+
+			return fields
+		}),
+	})
+}
+
+func (b *builder) fields(defs []*ast.FieldDefinition) gql.Fields {
+	fields := make(gql.Fields, len(defs))
+
+	for _, f := range defs {
+		args := make(gql.FieldConfigArgument, len(f.Arguments))
+
+		for _, a := range f.Arguments {
+			args[a.Name.Value] = &gql.ArgumentConfig{
+				Type:         b.typeOf(a.Type),
+				Description:  description(a.Description),
+				DefaultValue: defaultValue(a.DefaultValue),
+			}
+		}
+
+		fields[f.Name.Value] = &gql.Field{
+			Name:              f.Name.Value,
+			Type:              b.typeOf(f.Type),
+			Description:       description(f.Description),
+			Args:              args,
+			Resolve:           b.resolve,
+			DeprecationReason: deprecationReason(f.Directives),
+		}
+	}
+
+	return fields
+}
+
+func (b *builder) typeOf(t ast.Type) gql.Type {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return gql.NewNonNull(b.typeOf(t.Type))
+	case *ast.List:
+		return gql.NewList(b.typeOf(t.Type))
+	case *ast.Named:
+		named, err := b.named(t.Name.Value)
+		if err != nil {
+			return nil
+		}
+
+		return named
+	}
+
+	return nil
+}
+
+func description(s *ast.StringValue) string {
+	if nil == s {
+		return ""
+	}
+
+	return s.Value
+}
+
+func defaultValue(v ast.Value) interface{} {
+	if nil == v {
+		return nil
+	}
+
+	return v.GetValue()
+}
+
+func deprecationReason(directives []*ast.Directive) string {
+	for _, d := range directives {
+		if d.Name.Value != "deprecated" {
+			continue
+		}
+
+		for _, a := range d.Arguments {
+			if a.Name.Value == "reason" {
+				if s, ok := a.Value.GetValue().(string); ok {
+					return s
+				}
+			}
+		}
+
+		return gql.DefaultDeprecationReason
+	}
+
+	return ""
+}
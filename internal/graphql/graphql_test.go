@@ -0,0 +1,179 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/neotoolkit/faker"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/graphql"
+)
+
+const sdl = `
+interface Node {
+  id: ID!
+}
+
+enum Role {
+  ADMIN
+}
+
+type User implements Node {
+  id: ID!
+  name: String!
+  age: Int!
+  role: Role!
+  friends: [User!]!
+}
+
+input UserInput {
+  name: String!
+}
+
+union SearchResult = User
+
+type Query {
+  user(id: ID!): User
+  node(id: ID!): Node
+  search(text: String!): [SearchResult!]!
+}
+
+type Mutation {
+  createUser(input: UserInput!): User!
+}
+`
+
+func TestTypeError(t *testing.T) {
+	got := &graphql.TypeError{
+		Name: "test",
+	}
+
+	require.Equal(t, got.Error(), "unknown type test")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		sdl  string
+		err  error
+	}{
+		{
+			name: "schema",
+			sdl:  sdl,
+			err:  nil,
+		},
+		{
+			name: "without query type",
+			sdl:  "type User { id: ID! }",
+			err:  graphql.ErrEmptyQueryType,
+		},
+		{
+			name: "unknown type",
+			sdl:  "type Query { user: User }",
+			err:  &graphql.TypeError{Name: "User"},
+		},
+		{
+			name: "custom query type",
+			sdl:  "schema { query: Root } type Root { ok: Boolean! }",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := graphql.Parse([]byte(tc.sdl), faker.NewFaker())
+			if tc.err != nil {
+				require.EqualError(t, err, tc.err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, got)
+		})
+	}
+}
+
+func TestSchema_Do(t *testing.T) {
+	schema, err := graphql.Parse([]byte(sdl), faker.NewFaker())
+	require.NoError(t, err)
+
+	t.Run("query", func(t *testing.T) {
+		res := schema.Do(graphql.Params{
+			Query: `{ user(id: "1") { id name age role friends { name } } }`,
+		})
+		require.Empty(t, res.Errors)
+
+		user := res.Data.(map[string]interface{})["user"].(map[string]interface{})
+
+		require.IsType(t, "", user["id"])
+		require.IsType(t, "", user["name"])
+		require.IsType(t, 0, user["age"])
+		require.Equal(t, "ADMIN", user["role"])
+		require.Len(t, user["friends"], 2)
+	})
+
+	t.Run("interface and union", func(t *testing.T) {
+		res := schema.Do(graphql.Params{
+			Query: `{ node(id: "1") { __typename id } search(text: "a") { ... on User { name } } }`,
+		})
+		require.Empty(t, res.Errors)
+
+		data := res.Data.(map[string]interface{})
+
+		require.Equal(t, "User", data["node"].(map[string]interface{})["__typename"])
+		require.Len(t, data["search"], 2)
+	})
+
+	t.Run("mutation with variables", func(t *testing.T) {
+		res := schema.Do(graphql.Params{
+			Query:     `mutation Create($input: UserInput!) { createUser(input: $input) { id } }`,
+			Variables: map[string]interface{}{"input": map[string]interface{}{"name": "Elon"}},
+		})
+		require.Empty(t, res.Errors)
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		res := schema.Do(graphql.Params{
+			Query: `{ __schema { queryType { name } mutationType { name } types { name } } }`,
+		})
+		require.Empty(t, res.Errors)
+
+		s := res.Data.(map[string]interface{})["__schema"].(map[string]interface{})
+
+		require.Equal(t, "Query", s["queryType"].(map[string]interface{})["name"])
+		require.Equal(t, "Mutation", s["mutationType"].(map[string]interface{})["name"])
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		res := schema.Do(graphql.Params{
+			Query: `{ unknown }`,
+		})
+		require.NotEmpty(t, res.Errors)
+	})
+}
+
+func TestSchema_Do_InterfaceImplementations(t *testing.T) {
+	const implementations = `
+interface Node { id: ID! }
+type Post implements Node { id: ID! title: String! }
+type User implements Node { id: ID! name: String! }
+type Comment implements Node { id: ID! text: String! }
+type Query { node(id: ID!): Node }
+`
+
+	for i := 0; i < 20; i++ {
+		schema, err := graphql.Parse([]byte(implementations), faker.NewFaker())
+		require.NoError(t, err)
+
+		res := schema.Do(graphql.Params{
+			Query: `{ node(id: "1") { __typename ... on Post { title } } }`,
+		})
+		require.Empty(t, res.Errors)
+
+		node := res.Data.(map[string]interface{})["node"].(map[string]interface{})
+
+		require.Equal(t, "Post", node["__typename"])
+		require.IsType(t, "", node["title"])
+	}
+}
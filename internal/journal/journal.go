@@ -0,0 +1,269 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLimit is number of entries which are kept by journal, older entries are dropped
+const DefaultLimit = 1000
+
+// Entry is request received by server
+type Entry struct {
+	ID     int64       `json:"id"`
+	Time   time.Time   `json:"time"`
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  url.Values  `json:"query,omitempty"`
+	Header http.Header `json:"header"`
+	// Body is decoded JSON body, other bodies are kept as string
+	Body interface{} `json:"body,omitempty"`
+	// Operation is matched operation of specification, e.g. GET /users/{userId}, empty for unspecified requests
+	Operation string `json:"operation,omitempty"`
+	Status    int    `json:"status"`
+}
+
+// DecodeBody returns decoded JSON body or body as string if it is not JSON, empty body is nil
+func DecodeBody(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var body interface{}
+
+	if err := json.Unmarshal(data, &body); err != nil {
+		return string(data)
+	}
+
+	return body
+}
+
+// Journal is concurrency-safe bounded list of received requests
+type Journal struct {
+	mu     sync.RWMutex
+	limit  int
+	lastID int64
+	// entries are ring buffer if journal is full, the oldest entry is at start
+	entries []Entry
+	start   int
+}
+
+// NewJournal returns a new instance of Journal which keeps limit last entries
+func NewJournal(limit int) *Journal {
+	return &Journal{
+		limit: limit,
+	}
+}
+
+// Add appends entry with next identifier, the oldest entry is dropped if journal is full
+func (j *Journal) Add(e Entry) Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.lastID++
+	e.ID = j.lastID
+
+	// the oldest entry is overwritten, so adding to full journal does not move entries
+	if j.limit > 0 && len(j.entries) >= j.limit {
+		j.entries[j.start] = e
+		j.start = (j.start + 1) % len(j.entries)
+
+		return e
+	}
+
+	j.entries = append(j.entries, e)
+
+	return e
+}
+
+// each calls fn for every entry in order of receiving
+func (j *Journal) each(fn func(e Entry)) {
+	for i := range j.entries {
+		fn(j.entries[(j.start+i)%len(j.entries)])
+	}
+}
+
+// Entries returns entries which match filter in order of receiving
+func (j *Journal) Entries(f Filter) []Entry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	res := make([]Entry, 0, len(j.entries))
+
+	j.each(func(e Entry) {
+		if f.Match(e) {
+			res = append(res, e)
+		}
+	})
+
+	return res
+}
+
+// Count returns number of entries which match filter
+func (j *Journal) Count(f Filter) int {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	n := 0
+
+	j.each(func(e Entry) {
+		if f.Match(e) {
+			n++
+		}
+	})
+
+	return n
+}
+
+// Reset removes all entries
+func (j *Journal) Reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = nil
+	j.start = 0
+}
+
+// FilterError -.
+type FilterError struct {
+	Key     string
+	Message string
+}
+
+// Error -.
+func (e *FilterError) Error() string {
+	return "filter " + e.Key + ": " + e.Message
+}
+
+// Filter selects journal entries, empty fields match any entry
+type Filter struct {
+	Method    string
+	Path      string
+	Operation string
+	Status    int
+	// Header maps header name to value
+	Header map[string]string
+	// Body maps dot-separated path of body field to value, e.g. address.city
+	Body map[string]string
+}
+
+// ParseFilter returns filter from query parameters: method, path, operation, status, header.<name> and body.<field>
+func ParseFilter(q url.Values) (Filter, error) {
+	var f Filter
+
+	for key, values := range q {
+		value := values[0]
+
+		switch {
+		case key == "method":
+			f.Method = strings.ToUpper(value)
+		case key == "path":
+			f.Path = value
+		case key == "operation":
+			f.Operation = value
+		case key == "status":
+			status, err := strconv.Atoi(value)
+			if err != nil {
+				return Filter{}, &FilterError{Key: key, Message: "must be integer, got " + value}
+			}
+
+			f.Status = status
+		case strings.HasPrefix(key, "header."):
+			if nil == f.Header {
+				f.Header = make(map[string]string)
+			}
+
+			f.Header[strings.TrimPrefix(key, "header.")] = value
+		case strings.HasPrefix(key, "body."):
+			if nil == f.Body {
+				f.Body = make(map[string]string)
+			}
+
+			f.Body[strings.TrimPrefix(key, "body.")] = value
+		default:
+			return Filter{}, &FilterError{Key: key, Message: "unknown filter"}
+		}
+	}
+
+	return f, nil
+}
+
+// Match returns true if entry matches every field of filter
+func (f Filter) Match(e Entry) bool {
+	if f.Method != "" && f.Method != e.Method {
+		return false
+	}
+
+	if f.Path != "" && f.Path != e.Path {
+		return false
+	}
+
+	if f.Operation != "" && f.Operation != e.Operation {
+		return false
+	}
+
+	if f.Status != 0 && f.Status != e.Status {
+		return false
+	}
+
+	for name, value := range f.Header {
+		if e.Header.Get(name) != value {
+			return false
+		}
+	}
+
+	for path, value := range f.Body {
+		field, ok := lookup(e.Body, path)
+		if !ok || format(field) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookup returns field of body by dot-separated path, array items are selected by index
+func lookup(body interface{}, path string) (interface{}, bool) {
+	for _, key := range strings.Split(path, ".") {
+		switch v := body.(type) {
+		case map[string]interface{}:
+			field, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+
+			body = field
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+
+			body = v[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return body, true
+}
+
+// format returns value as it is written in filter, strings without quotes and other values as JSON
+func format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,194 @@
+package journal_test
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/journal"
+)
+
+func TestDecodeBody(t *testing.T) {
+	require.Nil(t, journal.DecodeBody(nil))
+	require.Equal(t, map[string]interface{}{"firstName": "Elon"}, journal.DecodeBody([]byte(`{"firstName":"Elon"}`)))
+	require.Equal(t, "firstName=Elon", journal.DecodeBody([]byte(`firstName=Elon`)))
+}
+
+func TestJournal_Add(t *testing.T) {
+	j := journal.NewJournal(2)
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		j.Add(journal.Entry{Method: http.MethodGet, Path: path})
+	}
+
+	entries := j.Entries(journal.Filter{})
+
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(2), entries[0].ID)
+	require.Equal(t, "/b", entries[0].Path)
+	require.Equal(t, int64(3), entries[1].ID)
+	require.Equal(t, "/c", entries[1].Path)
+
+	j.Reset()
+
+	require.Zero(t, j.Count(journal.Filter{}))
+	require.Equal(t, int64(4), j.Add(journal.Entry{}).ID)
+}
+
+func TestJournal_Add_Wrap(t *testing.T) {
+	j := journal.NewJournal(3)
+
+	for _, path := range []string{"/a", "/b", "/c", "/d", "/e", "/f", "/g"} {
+		j.Add(journal.Entry{Method: http.MethodGet, Path: path})
+	}
+
+	var paths []string
+	for _, e := range j.Entries(journal.Filter{}) {
+		paths = append(paths, e.Path)
+	}
+
+	require.Equal(t, []string{"/e", "/f", "/g"}, paths)
+	require.Equal(t, 3, j.Count(journal.Filter{Method: http.MethodGet}))
+
+	j.Reset()
+	j.Add(journal.Entry{Path: "/h"})
+
+	require.Equal(t, "/h", j.Entries(journal.Filter{})[0].Path)
+}
+
+func TestJournal_Concurrency(t *testing.T) {
+	j := journal.NewJournal(journal.DefaultLimit)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			j.Add(journal.Entry{Method: http.MethodPost})
+			j.Count(journal.Filter{Method: http.MethodPost})
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, 100, j.Count(journal.Filter{Method: http.MethodPost}))
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  journal.Filter
+		err   string
+	}{
+		{
+			name:  "empty",
+			query: "",
+			want:  journal.Filter{},
+		},
+		{
+			name:  "fields",
+			query: "method=post&path=/users&operation=POST /users&status=201&header.X-Request-Id=1&body.firstName=Elon",
+			want: journal.Filter{
+				Method:    http.MethodPost,
+				Path:      "/users",
+				Operation: "POST /users",
+				Status:    http.StatusCreated,
+				Header:    map[string]string{"X-Request-Id": "1"},
+				Body:      map[string]string{"firstName": "Elon"},
+			},
+		},
+		{
+			name:  "wrong status",
+			query: "status=created",
+			err:   "filter status: must be integer, got created",
+		},
+		{
+			name:  "unknown filter",
+			query: "name=Elon",
+			err:   "filter name: unknown filter",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+
+			got, err := journal.ParseFilter(q)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	e := journal.Entry{
+		Method:    http.MethodPost,
+		Path:      "/users",
+		Header:    http.Header{"X-Request-Id": []string{"1"}},
+		Body:      journal.DecodeBody([]byte(`{"firstName":"Elon","age":50,"address":{"city":"Austin"},"tags":["ceo"]}`)),
+		Operation: "POST /users",
+		Status:    http.StatusCreated,
+	}
+
+	tests := []struct {
+		name   string
+		filter journal.Filter
+		want   bool
+	}{
+		{
+			name:   "empty",
+			filter: journal.Filter{},
+			want:   true,
+		},
+		{
+			name:   "method",
+			filter: journal.Filter{Method: http.MethodGet},
+			want:   false,
+		},
+		{
+			name:   "status",
+			filter: journal.Filter{Operation: "POST /users", Status: http.StatusCreated},
+			want:   true,
+		},
+		{
+			name:   "header",
+			filter: journal.Filter{Header: map[string]string{"x-request-id": "1"}},
+			want:   true,
+		},
+		{
+			name: "body",
+			filter: journal.Filter{Body: map[string]string{
+				"firstName":    "Elon",
+				"age":          "50",
+				"address.city": "Austin",
+				"tags.0":       "ceo",
+			}},
+			want: true,
+		},
+		{
+			name:   "missing body field",
+			filter: journal.Filter{Body: map[string]string{"lastName": "Musk"}},
+			want:   false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.filter.Match(e))
+		})
+	}
+}
@@ -27,6 +27,10 @@ func setLevel(level string) zerolog.Level {
 		return zerolog.DebugLevel
 	case "INFO":
 		return zerolog.InfoLevel
+	case "WARN":
+		return zerolog.WarnLevel
+	case "ERROR":
+		return zerolog.ErrorLevel
 	default:
 		return zerolog.InfoLevel
 	}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/config"
+)
+
+// defaultMethods are allowed methods if configuration has no methods
+var defaultMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// CORS sets CORS headers of responses and answers preflight requests
+func CORS(next http.Handler, cors config.CORS) http.Handler {
+	if !cors.Enabled {
+		return next
+	}
+
+	methods := cors.AllowMethods
+	if len(methods) == 0 {
+		methods = defaultMethods
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !allowedOrigin(cors.AllowOrigins, origin) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+		if len(cors.AllowHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(cors.AllowHeaders, ", "))
+		} else if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func allowedOrigin(origins []string, origin string) bool {
+	if len(origins) == 0 {
+		return true
+	}
+
+	for _, o := range origins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+
+	return false
+}
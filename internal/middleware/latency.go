@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Latency delays every response, request is not handled if client cancels it during delay
+func Latency(next http.Handler, latency time.Duration) http.Handler {
+	if latency <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"sort"
+)
+
+// Content -.
+type Content map[string]*MediaType
+
+// MediaType -.
+type MediaType struct {
+	Schema   Schema      `json:"schema" yaml:"schema"`
+	Example  interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples Examples    `json:"examples,omitempty" yaml:"examples,omitempty"`
+	// Encoding describes parts of multipart request body by property name
+	Encoding map[string]*Encoding `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+}
+
+// Encoding -.
+type Encoding struct {
+	// ContentType is comma-separated list of content types of part, e.g. image/png, image/jpeg
+	ContentType string `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+}
+
+// Example -.
+type Example struct {
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Examples -.
+type Examples map[string]Example
+
+// GetKeys returns sorted keys of examples
+func (e Examples) GetKeys() []string {
+	keys := make([]string, 0, len(e))
+
+	for k := range e {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ExampleToResponse returns example value in form which is used for response
+func ExampleToResponse(data interface{}) interface{} {
+	switch d := data.(type) {
+	case []interface{}:
+		res := make([]map[string]interface{}, len(d))
+
+		for k, v := range d {
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return d
+			}
+
+			res[k] = obj
+		}
+
+		return res
+	default:
+		return d
+	}
+}
@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// SchemaError -.
+type SchemaError struct {
+	Ref string
+}
+
+// Error -.
+func (e *SchemaError) Error() string {
+	return "unknown schema " + e.Ref
+}
+
+// OpenAPI is struct for OpenAPI 3 specification
+type OpenAPI struct {
+	OpenAPI    string     `json:"openapi" yaml:"openapi"`
+	Info       Info       `json:"info" yaml:"info"`
+	Servers    Servers    `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      Paths      `json:"paths" yaml:"paths"`
+	Components Components `json:"components,omitempty" yaml:"components,omitempty"`
+	Security   []Security `json:"security,omitempty" yaml:"security,omitempty"`
+	Tags       Tags       `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// LookupByReference returns schema by reference like #/components/schemas/User
+func (api OpenAPI) LookupByReference(ref string) (Schema, error) {
+	schema := api.Components.Schemas[schemaKey(ref)]
+	if nil == schema {
+		return Schema{}, &SchemaError{Ref: ref}
+	}
+
+	return *schema, nil
+}
+
+func schemaKey(ref string) string {
+	const prefix = "#/components/schemas/"
+	return strings.TrimPrefix(ref, prefix)
+}
+
+// ParameterError -.
+type ParameterError struct {
+	Ref string
+}
+
+// Error -.
+func (e *ParameterError) Error() string {
+	return "unknown parameter " + e.Ref
+}
+
+// LookupParameter returns parameter by reference like #/components/parameters/Limit
+func (api OpenAPI) LookupParameter(ref string) (Parameter, error) {
+	const prefix = "#/components/parameters/"
+
+	param := api.Components.Parameters[strings.TrimPrefix(ref, prefix)]
+	if nil == param {
+		return Parameter{}, &ParameterError{Ref: ref}
+	}
+
+	return *param, nil
+}
+
+// HeaderError -.
+type HeaderError struct {
+	Ref string
+}
+
+// Error -.
+func (e *HeaderError) Error() string {
+	return "unknown header " + e.Ref
+}
+
+// LookupHeader returns header by reference like #/components/headers/RateLimit
+func (api OpenAPI) LookupHeader(ref string) (Header, error) {
+	const prefix = "#/components/headers/"
+
+	header := api.Components.Headers[strings.TrimPrefix(ref, prefix)]
+	if nil == header {
+		return Header{}, &HeaderError{Ref: ref}
+	}
+
+	return *header, nil
+}
+
+// Info -.
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// Server -.
+type Server struct {
+	URL         string                     `json:"url" yaml:"url"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// ServerVariable is value of variable in braces of server URL
+type ServerVariable struct {
+	Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default     string   `json:"default" yaml:"default"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ServerVariableError -.
+type ServerVariableError struct {
+	URL  string
+	Name string
+}
+
+// Error -.
+func (e *ServerVariableError) Error() string {
+	return "server " + e.URL + " has undefined variable " + e.Name
+}
+
+var serverVariable = regexp.MustCompile(`{([^{}]*)}`)
+
+// Expand returns URL of server with default values of variables
+func (s Server) Expand() (string, error) {
+	var err error
+
+	expanded := serverVariable.ReplaceAllStringFunc(s.URL, func(v string) string {
+		name := v[1 : len(v)-1]
+
+		variable := s.Variables[name]
+		if nil == variable {
+			if err == nil {
+				err = &ServerVariableError{URL: s.URL, Name: name}
+			}
+
+			return v
+		}
+
+		return variable.Default
+	})
+
+	return expanded, err
+}
+
+// BasePath returns path of server URL without trailing slash, e.g. /v2 for https://{env}.example.com/{version}
+// Base path of root is empty
+func (s Server) BasePath() (string, error) {
+	expanded, err := s.Expand()
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(expanded)
+	if err != nil {
+		return "", err
+	}
+
+	path := u.Path
+	if u.Scheme == "" && u.Host == "" && !strings.HasPrefix(path, "/") {
+		// relative URL like v2 is relative to root
+		path = "/" + path
+	}
+
+	return strings.TrimRight(path, "/"), nil
+}
+
+// BasePath returns base path of the first server, operations are served under it
+func (api OpenAPI) BasePath() (string, error) {
+	if len(api.Servers) == 0 || nil == api.Servers[0] {
+		return "", nil
+	}
+
+	return api.Servers[0].BasePath()
+}
+
+// Servers -.
+type Servers []*Server
+
+// Components -.
+type Components struct {
+	Schemas    Schemas               `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Parameters map[string]*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Headers    map[string]*Header    `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// Security -.
+type Security map[string][]string
+
+// Tag -.
+type Tag struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Tags -.
+type Tags []*Tag
@@ -0,0 +1,82 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func TestServerVariableError(t *testing.T) {
+	err := &openapi.ServerVariableError{URL: "/{version}", Name: "version"}
+
+	require.EqualError(t, err, "server /{version} has undefined variable version")
+}
+
+func TestServer_BasePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		server openapi.Server
+		want   string
+		err    error
+	}{
+		{
+			name:   "root",
+			server: openapi.Server{URL: "https://api.example.com"},
+			want:   "",
+		},
+		{
+			name:   "root with trailing slash",
+			server: openapi.Server{URL: "/"},
+			want:   "",
+		},
+		{
+			name:   "absolute URL",
+			server: openapi.Server{URL: "https://api.example.com/v1/"},
+			want:   "/v1",
+		},
+		{
+			name:   "relative URL",
+			server: openapi.Server{URL: "api/v1"},
+			want:   "/api/v1",
+		},
+		{
+			name: "variables",
+			server: openapi.Server{
+				URL: "https://{env}.example.com/{basePath}",
+				Variables: map[string]*openapi.ServerVariable{
+					"env":      {Default: "staging"},
+					"basePath": {Enum: []string{"v1", "v2"}, Default: "v2"},
+				},
+			},
+			want: "/v2",
+		},
+		{
+			name:   "undefined variable",
+			server: openapi.Server{URL: "https://api.example.com/{basePath}"},
+			err:    &openapi.ServerVariableError{URL: "https://api.example.com/{basePath}", Name: "basePath"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.server.BasePath()
+
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestOpenAPI_BasePath(t *testing.T) {
+	got, err := openapi.OpenAPI{}.BasePath()
+
+	require.NoError(t, err)
+	require.Equal(t, "", got)
+
+	got, err = openapi.OpenAPI{Servers: openapi.Servers{{URL: "/v2"}, {URL: "/v1"}}}.BasePath()
+
+	require.NoError(t, err)
+	require.Equal(t, "/v2", got)
+}
@@ -0,0 +1,67 @@
+package openapi
+
+// Paths -.
+type Paths map[string]*Path
+
+// Path -.
+type Path struct {
+	Post       *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Get        *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Put        *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Patch      *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete     *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Head       *Operation `json:"head,omitempty" yaml:"head,omitempty"`
+	Options    *Operation `json:"options,omitempty" yaml:"options,omitempty"`
+	Trace      *Operation `json:"trace,omitempty" yaml:"trace,omitempty"`
+	Parameters Parameters `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// Operation -.
+type Operation struct {
+	Parameters  Parameters  `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody RequestBody `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   Responses   `json:"responses" yaml:"responses"`
+	// Proxy is true if operation is proxied to upstream instead of mocking
+	Proxy bool `json:"x-dummy-proxy,omitempty" yaml:"x-dummy-proxy,omitempty"`
+}
+
+// Parameter -.
+type Parameter struct {
+	Ref      string  `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name     string  `json:"name,omitempty" yaml:"name,omitempty"`
+	In       string  `json:"in,omitempty" yaml:"in,omitempty"`
+	Required bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Style    string  `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode  *bool   `json:"explode,omitempty" yaml:"explode,omitempty"`
+	Schema   *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Parameters -.
+type Parameters []Parameter
+
+// RequestBody -.
+type RequestBody struct {
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     Content `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Response -.
+type Response struct {
+	Description *string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Headers     map[string]*Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     Content            `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Header is response header
+type Header struct {
+	Ref         string      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool        `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples    Examples    `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+// Responses -.
+type Responses map[string]*Response
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"encoding/json"
+)
+
+// Schema -.
+type Schema struct {
+	Properties Schemas     `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Type       string      `json:"type,omitempty" yaml:"type,omitempty"`
+	Format     string      `json:"format,omitempty" yaml:"format,omitempty"`
+	Default    interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	Example    interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	Required   []string    `json:"required,omitempty" yaml:"required,omitempty"`
+	Items      *Schema     `json:"items,omitempty" yaml:"items,omitempty"`
+	Ref        string      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Nullable   bool        `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	ReadOnly   bool        `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	WriteOnly  bool        `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
+
+	Enum             []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+	MultipleOf       *float64      `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Minimum          *float64      `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum          *float64      `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	MinLength        *uint64       `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength        *uint64       `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Pattern          string        `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinItems         *uint64       `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems         *uint64       `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems      bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	MinProperties    *uint64       `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+	MaxProperties    *uint64       `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+
+	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+
+	AllOf         []*Schema      `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	OneOf         []*Schema      `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AnyOf         []*Schema      `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	Discriminator *Discriminator `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+
+	XML *XML `json:"xml,omitempty" yaml:"xml,omitempty"`
+
+	Faker string `json:"x-faker,omitempty" yaml:"x-faker,omitempty"`
+}
+
+// XML describes XML representation of schema
+type XML struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty" yaml:"wrapped,omitempty"`
+}
+
+// Schemas -.
+type Schemas map[string]*Schema
+
+// Discriminator -.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName" yaml:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+}
+
+// discriminator is Discriminator without custom unmarshalling
+type discriminator Discriminator
+
+// UnmarshalYAML accepts property name as string like discriminator of Swagger 2.0
+func (d *Discriminator) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+
+	if err := unmarshal(&name); err == nil {
+		d.PropertyName = name
+
+		return nil
+	}
+
+	return unmarshal((*discriminator)(d))
+}
+
+// UnmarshalJSON accepts property name as string like discriminator of Swagger 2.0
+func (d *Discriminator) UnmarshalJSON(data []byte) error {
+	var name string
+
+	if err := json.Unmarshal(data, &name); err == nil {
+		d.PropertyName = name
+
+		return nil
+	}
+
+	return json.Unmarshal(data, (*discriminator)(d))
+}
+
+// AdditionalProperties is boolean or schema of additionalProperties keyword
+type AdditionalProperties struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+// UnmarshalYAML -.
+func (a *AdditionalProperties) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var allowed bool
+
+	if err := unmarshal(&allowed); err == nil {
+		a.Allowed = allowed
+
+		return nil
+	}
+
+	var schema Schema
+
+	if err := unmarshal(&schema); err != nil {
+		return err
+	}
+
+	a.Allowed = true
+	a.Schema = &schema
+
+	return nil
+}
+
+// UnmarshalJSON -.
+func (a *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	var allowed bool
+
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Allowed = allowed
+
+		return nil
+	}
+
+	var schema Schema
+
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return err
+	}
+
+	a.Allowed = true
+	a.Schema = &schema
+
+	return nil
+}
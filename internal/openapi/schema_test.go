@@ -0,0 +1,83 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+func TestAdditionalProperties_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		json string
+		want *openapi.AdditionalProperties
+	}{
+		{
+			name: "false",
+			yaml: "additionalProperties: false",
+			json: `{"additionalProperties": false}`,
+			want: &openapi.AdditionalProperties{Allowed: false},
+		},
+		{
+			name: "true",
+			yaml: "additionalProperties: true",
+			json: `{"additionalProperties": true}`,
+			want: &openapi.AdditionalProperties{Allowed: true},
+		},
+		{
+			name: "schema",
+			yaml: "additionalProperties:\n  type: integer",
+			json: `{"additionalProperties": {"type": "integer"}}`,
+			want: &openapi.AdditionalProperties{Allowed: true, Schema: &openapi.Schema{Type: "integer"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var fromYAML, fromJSON openapi.Schema
+
+			require.NoError(t, yaml.Unmarshal([]byte(tc.yaml), &fromYAML))
+			require.NoError(t, json.Unmarshal([]byte(tc.json), &fromJSON))
+			require.Equal(t, tc.want, fromYAML.AdditionalProperties)
+			require.Equal(t, tc.want, fromJSON.AdditionalProperties)
+		})
+	}
+}
+
+func TestDiscriminator_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		json string
+		want *openapi.Discriminator
+	}{
+		{
+			name: "string",
+			yaml: "discriminator: petType",
+			json: `{"discriminator": "petType"}`,
+			want: &openapi.Discriminator{PropertyName: "petType"},
+		},
+		{
+			name: "object",
+			yaml: "discriminator:\n  propertyName: petType\n  mapping:\n    dog: '#/components/schemas/Dog'",
+			json: `{"discriminator": {"propertyName": "petType", "mapping": {"dog": "#/components/schemas/Dog"}}}`,
+			want: &openapi.Discriminator{PropertyName: "petType", Mapping: map[string]string{"dog": "#/components/schemas/Dog"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var fromYAML, fromJSON openapi.Schema
+
+			require.NoError(t, yaml.Unmarshal([]byte(tc.yaml), &fromYAML))
+			require.NoError(t, json.Unmarshal([]byte(tc.json), &fromJSON))
+			require.Equal(t, tc.want, fromYAML.Discriminator)
+			require.Equal(t, tc.want, fromJSON.Discriminator)
+		})
+	}
+}
@@ -1,23 +1,33 @@
 package parse
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/goccy/go-yaml"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
 	"github.com/neotoolkit/faker"
-	"github.com/neotoolkit/openapi"
 
 	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/graphql"
+	"github.com/neotoolkit/dummy/internal/openapi"
 	"github.com/neotoolkit/dummy/internal/read"
+	"github.com/neotoolkit/dummy/internal/swagger"
 )
 
 type SpecType string
 
 const (
-	OpenAPI SpecType = "OpenAPI"
-	GraphQL SpecType = "GraphQL"
-	Unknown SpecType = "Unknown"
+	OpenAPI  SpecType = "OpenAPI"
+	Swagger  SpecType = "Swagger"
+	AsyncAPI SpecType = "AsyncAPI"
+	GraphQL  SpecType = "GraphQL"
+	Unknown  SpecType = "Unknown"
 )
 
 var ErrEmptySpecTypePath = errors.New("empty spec type path")
@@ -43,37 +53,150 @@ func (e *SpecFileError) Error() string {
 }
 
 // Parse -.
-func Parse(path string) (api.API, error) {
+func Parse(path string, opts ...Option) (api.API, error) {
 	file, err := read.Read(path)
 	if err != nil {
 		return api.API{}, err
 	}
 
-	specType, err := GetSpecType(path)
+	specType, err := SpecTypeOf(path, file)
 	if err != nil {
 		return api.API{}, err
 	}
 
 	switch specType {
-	case OpenAPI:
-		oapi, err := openapi.Parse(file)
+	case OpenAPI, Swagger:
+		oapi, err := decode(file, specType)
 		if err != nil {
 			return api.API{}, err
 		}
 
-		f := faker.NewFaker()
+		return build(oapi, opts...)
+	case GraphQL:
+		schema, err := graphql.Parse(file, faker.NewFaker())
+		if err != nil {
+			return api.API{}, err
+		}
+
+		// router is built once, so requests to GraphQL API do not rebuild it
+		a := api.NewAPI(nil)
+		a.GraphQL = schema
 
-		b := &api.Builder{
-			OpenAPI: oapi,
-			Faker:   f,
+		return a, nil
+	}
+
+	return api.API{}, &SpecTypeError{
+		Path: path,
+	}
+}
+
+// Validate returns all problems of specification, error is returned if specification can not be read
+func Validate(path string) ([]api.Problem, error) {
+	file, err := read.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	specType, err := SpecTypeOf(path, file)
+	if err != nil {
+		return nil, err
+	}
+
+	switch specType {
+	case OpenAPI, Swagger:
+		oapi, err := decode(file, specType)
+		if err != nil {
+			return []api.Problem{{Message: err.Error()}}, nil
 		}
 
-		return b.Build()
+		return api.Lint(oapi), nil
 	case GraphQL:
-		return api.API{}, nil
+		if _, err := graphql.Parse(file, faker.NewFaker()); err != nil {
+			return []api.Problem{{Message: err.Error()}}, nil
+		}
+
+		return nil, nil
 	}
 
-	return api.API{}, nil
+	return nil, &SpecTypeError{
+		Path: path,
+	}
+}
+
+// decode returns OpenAPI specification, Swagger specification is converted to OpenAPI
+func decode(file []byte, specType SpecType) (openapi.OpenAPI, error) {
+	if specType == Swagger {
+		var s swagger.Swagger
+
+		if err := unmarshal(file, &s); err != nil {
+			return openapi.OpenAPI{}, err
+		}
+
+		return s.OpenAPI()
+	}
+
+	var oapi openapi.OpenAPI
+
+	if err := unmarshal(file, &oapi); err != nil {
+		return openapi.OpenAPI{}, err
+	}
+
+	return oapi, nil
+}
+
+// Option configures building of API from OpenAPI or Swagger specification
+type Option func(b *api.Builder)
+
+// WithExamplePolicy sets policy for response examples which do not match their schemas, warn is called for reported examples
+func WithExamplePolicy(policy api.ExamplePolicy, warn func(err error)) Option {
+	return func(b *api.Builder) {
+		b.ExamplePolicy = policy
+		b.Warn = warn
+	}
+}
+
+// WithBasePath serves operations under base path instead of base path of servers of specification, / serves them at root
+func WithBasePath(path string) Option {
+	return func(b *api.Builder) {
+		b.BasePath = path
+	}
+}
+
+func build(oapi openapi.OpenAPI, opts ...Option) (api.API, error) {
+	f := faker.NewFaker()
+
+	b := &api.Builder{
+		OpenAPI: oapi,
+		Faker:   f,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b.Build()
+}
+
+// unmarshal decodes JSON or YAML document
+func unmarshal(data []byte, v interface{}) error {
+	if isJSON(data) {
+		return json.Unmarshal(data, v)
+	}
+
+	return yaml.Unmarshal(data, v)
+}
+
+func isJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[')
+}
+
+// specVersion contains fields which define specification type
+type specVersion struct {
+	OpenAPI  string `json:"openapi" yaml:"openapi"`
+	Swagger  string `json:"swagger" yaml:"swagger"`
+	AsyncAPI string `json:"asyncapi" yaml:"asyncapi"`
 }
 
 // GetSpecType returns specification type for path
@@ -82,36 +205,73 @@ func GetSpecType(path string) (SpecType, error) {
 		return Unknown, ErrEmptySpecTypePath
 	}
 
-	splitPath := strings.Split(path[1:], ".")
+	file, err := read.Read(path)
+	if err != nil {
+		return Unknown, err
+	}
+
+	return SpecTypeOf(path, file)
+}
+
+// SpecTypeOf returns specification type by content of specification, path is used only as hint
+func SpecTypeOf(path string, file []byte) (SpecType, error) {
+	var version specVersion
+
+	if err := unmarshal(file, &version); err == nil {
+		switch {
+		case len(version.OpenAPI) > 0:
+			return OpenAPI, nil
+		case strings.HasPrefix(version.Swagger, "2."):
+			return Swagger, nil
+		case len(version.AsyncAPI) > 0:
+			return AsyncAPI, nil
+		}
+	}
+
+	ext := extension(path)
 
-	if len(splitPath) == 1 {
+	if isGraphQL(file) || ext == "graphql" || ext == "gql" {
+		return GraphQL, nil
+	}
+
+	if ext == "" {
 		return Unknown, &SpecFileError{
 			Path: path,
 		}
 	}
 
-	file, err := read.Read(path)
-	if err != nil {
-		return Unknown, err
+	return Unknown, &SpecTypeError{
+		Path: path,
 	}
+}
 
-	switch splitPath[len(splitPath)-1] {
-	case "yml", "yaml":
-		var openapi openapi.OpenAPI
+// isGraphQL returns true if file is GraphQL SDL with at least one type system definition
+func isGraphQL(file []byte) bool {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: string(file),
+	})
+	if err != nil {
+		return false
+	}
 
-		err := yaml.Unmarshal(file, &openapi)
-		if err != nil || len(openapi.OpenAPI) == 0 {
-			return Unknown, &SpecTypeError{
-				Path: path,
-			}
+	for _, def := range doc.Definitions {
+		switch def.(type) {
+		case *ast.SchemaDefinition, *ast.ScalarDefinition, *ast.ObjectDefinition, *ast.InterfaceDefinition,
+			*ast.UnionDefinition, *ast.EnumDefinition, *ast.InputObjectDefinition, *ast.TypeExtensionDefinition:
+			return true
 		}
+	}
 
-		return OpenAPI, nil
-	case "graphql":
-		return GraphQL, nil
-	default:
-		return Unknown, &SpecTypeError{
-			Path: path,
+	return false
+}
+
+// extension returns lower-cased file extension without dot for path or URL
+func extension(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		if u, err := url.Parse(path); err == nil {
+			path = u.Path
 		}
 	}
+
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
 }
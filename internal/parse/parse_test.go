@@ -3,6 +3,9 @@ package parse_test
 import (
 	"errors"
 	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 
@@ -47,17 +50,19 @@ func TestParse(t *testing.T) {
 		},
 		{
 			name: "file without format",
-			path: "./testdata/openapi",
+			path: "./testdata/unknown",
 			want: api.API{},
 			err: &parse.SpecFileError{
-				Path: "./testdata/openapi",
+				Path: "./testdata/unknown",
 			},
 		},
 		{
-			name: "graphql",
-			path: "./testdata/schema.graphql",
+			name: "asyncapi",
+			path: "./testdata/asyncapi.yml",
 			want: api.API{},
-			err:  nil,
+			err: &parse.SpecTypeError{
+				Path: "./testdata/asyncapi.yml",
+			},
 		},
 		{
 			name: "",
@@ -70,7 +75,7 @@ func TestParse(t *testing.T) {
 		{
 			name: "",
 			path: "./testdata/openapi.yml",
-			want: api.API{},
+			want: api.NewAPI(nil),
 			err:  nil,
 		},
 		{
@@ -95,38 +100,36 @@ func TestParse(t *testing.T) {
 }
 
 func TestParse_YAML(t *testing.T) {
+	user := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"id":        api.StringSchema{Example: "380ed0b7-eb21-4ad4-acd0-efa90cf69c6a", Format: "uuid"},
+			"firstName": api.StringSchema{Example: "Larry"},
+			"lastName":  api.StringSchema{Example: "Page"},
+		},
+		Example:  map[string]interface{}{},
+		Required: []string{"id", "firstName", "lastName"},
+	}
+
 	expected := api.API{
 		Operations: []api.Operation{
 			{
 				Method: "POST",
 				Path:   "/users",
-				Body: map[string]api.FieldType{
-					"id": {
-						Required: true,
-						Type:     "string",
-					},
-					"firstName": {
-						Required: true,
-						Type:     "string",
-					},
-					"lastName": {
-						Required: true,
-						Type:     "string",
+				RequestBody: &api.RequestBody{
+					Required: true,
+					Content: []api.RequestContent{
+						{
+							MediaType: "application/json",
+							Schema:    user,
+						},
 					},
 				},
 				Responses: []api.Response{
 					{
 						StatusCode: 201,
 						MediaType:  "application/json",
-						Schema: api.ObjectSchema{
-							Properties: map[string]api.Schema{
-								"id":        api.StringSchema{Example: "380ed0b7-eb21-4ad4-acd0-efa90cf69c6a"},
-								"firstName": api.StringSchema{Example: "Larry"},
-								"lastName":  api.StringSchema{Example: "Page"},
-							},
-							Example: map[string]interface{}{},
-						},
-						Examples: map[string]interface{}{},
+						Schema:     user,
+						Examples:   map[string]interface{}{},
 					},
 				},
 			},
@@ -138,15 +141,9 @@ func TestParse_YAML(t *testing.T) {
 						StatusCode: 200,
 						MediaType:  "application/json",
 						Schema: api.ArraySchema{
-							Type: api.ObjectSchema{
-								Properties: map[string]api.Schema{
-									"id":        api.StringSchema{Example: "380ed0b7-eb21-4ad4-acd0-efa90cf69c6a"},
-									"firstName": api.StringSchema{Example: "Larry"},
-									"lastName":  api.StringSchema{Example: "Page"},
-								},
-								Example: map[string]interface{}{},
-							},
+							Type:    user,
 							Example: []interface{}{},
+							Dynamic: true,
 						},
 						Example: []map[string]interface{}{
 							{
@@ -167,19 +164,21 @@ func TestParse_YAML(t *testing.T) {
 			{
 				Method: "GET",
 				Path:   "/users/{userId}",
+				Parameters: []api.Parameter{
+					{
+						Name:     "userId",
+						In:       "path",
+						Required: true,
+						Style:    "simple",
+						Schema:   api.StringSchema{},
+					},
+				},
 				Responses: []api.Response{
 					{
 						StatusCode: 200,
 						MediaType:  "application/json",
-						Schema: api.ObjectSchema{
-							Properties: map[string]api.Schema{
-								"id":        api.StringSchema{Example: "380ed0b7-eb21-4ad4-acd0-efa90cf69c6a"},
-								"firstName": api.StringSchema{Example: "Larry"},
-								"lastName":  api.StringSchema{Example: "Page"},
-							},
-							Example: map[string]interface{}{},
-						},
-						Examples: map[string]interface{}{},
+						Schema:     user,
+						Examples:   map[string]interface{}{},
 					},
 				},
 			},
@@ -192,11 +191,85 @@ func TestParse_YAML(t *testing.T) {
 	require.Equalf(t, testable(t, expected), testable(t, openapi), `parsed schema from "testdata/openapi3.yml"`)
 }
 
-func testable(t *testing.T, api api.API) api.API {
+func TestParse_Formats(t *testing.T) {
+	expected, err := parse.Parse("testdata/openapi3.yml")
+	require.NoError(t, err)
+
+	for _, path := range []string{"testdata/openapi3.json", "testdata/openapi"} {
+		t.Run(path, func(t *testing.T) {
+			got, err := parse.Parse(path)
+
+			require.NoError(t, err)
+			require.Equal(t, testable(t, expected), testable(t, got))
+		})
+	}
+}
+
+func TestParse_URLWithoutExtension(t *testing.T) {
+	file, err := ioutil.ReadFile("testdata/openapi3.json")
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write(file)
+	}))
+	defer ts.Close()
+
+	expected, err := parse.Parse("testdata/openapi3.yml")
+	require.NoError(t, err)
+
+	got, err := parse.Parse(ts.URL + "/v3/api-docs")
+
+	require.NoError(t, err)
+	require.Equal(t, testable(t, expected), testable(t, got))
+}
+
+func TestParse_Swagger(t *testing.T) {
+	expected, err := parse.Parse("testdata/openapi3.yml")
+	require.NoError(t, err)
+
+	swagger, err := parse.Parse("testdata/swagger.yml")
+
+	require.NoError(t, err)
+	require.Equalf(t, testable(t, expected), testable(t, swagger), `parsed schema from "testdata/swagger.yml"`)
+}
+
+func TestParse_GraphQL(t *testing.T) {
+	got, err := parse.Parse("./testdata/schema.graphql")
+
+	require.NoError(t, err)
+	require.Empty(t, got.Operations)
+	require.NotNil(t, got.GraphQL)
+	require.Same(t, got.Router(), got.Router())
+}
+
+func TestValidate(t *testing.T) {
+	problems, err := parse.Validate("testdata/invalid-openapi.yml")
+
+	require.NoError(t, err)
+	require.Equal(t, []api.Problem{
+		{Path: "/paths/~1users/get/responses/ok", Message: "status code must be number, got ok"},
+		{Path: "/paths/~1users/get/responses/ok/content/application~1json/schema", Message: "array must have items"},
+		{Path: "/paths/~1users~1{id}/get/responses/200/content/application~1json/schema/$ref", Message: "unresolvable reference #/components/schemas/Person"},
+		{Path: "/components/schemas/User/properties/age/example", Message: "must be integer, got string"},
+		{Path: "/components/schemas/User/properties/id/type", Message: "unknown type uuid"},
+	}, problems)
+
+	problems, err = parse.Validate("testdata/openapi3.yml")
+
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	_, err = parse.Validate("testdata/unknown")
+
+	require.Error(t, err)
+}
+
+func testable(t *testing.T, spec api.API) api.API {
 	t.Helper()
 
-	sort.Slice(api.Operations, func(i, j int) bool {
-		a, b := api.Operations[i], api.Operations[j]
+	sort.Slice(spec.Operations, func(i, j int) bool {
+		a, b := spec.Operations[i], spec.Operations[j]
 
 		if a.Method > b.Method {
 			return false
@@ -209,7 +282,7 @@ func testable(t *testing.T, api api.API) api.API {
 		return a.Path < b.Path
 	})
 
-	return api
+	return api.NewAPI(spec.Operations)
 }
 
 func TestGetSpecType(t *testing.T) {
@@ -226,13 +299,41 @@ func TestGetSpecType(t *testing.T) {
 			err:  parse.ErrEmptySpecTypePath,
 		},
 		{
-			name: "",
+			name: "not exist file",
 			path: "./testdata/openapi3",
 			want: parse.Unknown,
-			err: &parse.SpecFileError{
+			err: &fs.PathError{
+				Op:   "open",
 				Path: "./testdata/openapi3",
+				Err:  errors.New("no such file or directory"),
 			},
 		},
+		{
+			name: "file without format",
+			path: "./testdata/unknown",
+			want: parse.Unknown,
+			err: &parse.SpecFileError{
+				Path: "./testdata/unknown",
+			},
+		},
+		{
+			name: "file without extension",
+			path: "./testdata/openapi",
+			want: parse.OpenAPI,
+			err:  nil,
+		},
+		{
+			name: "json",
+			path: "./testdata/openapi3.json",
+			want: parse.OpenAPI,
+			err:  nil,
+		},
+		{
+			name: "asyncapi",
+			path: "./testdata/asyncapi.yml",
+			want: parse.AsyncAPI,
+			err:  nil,
+		},
 		{
 			name: "",
 			path: "./testdata/openapi3.yml",
@@ -253,6 +354,12 @@ func TestGetSpecType(t *testing.T) {
 			want: parse.GraphQL,
 			err:  nil,
 		},
+		{
+			name: "swagger",
+			path: "./testdata/swagger.yml",
+			want: parse.Swagger,
+			err:  nil,
+		},
 	}
 
 	for _, tc := range tests {
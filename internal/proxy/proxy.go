@@ -0,0 +1,28 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/neotoolkit/dummy/internal/logger"
+)
+
+// New returns reverse proxy to target, requests are sent with Host of target
+// Requests which can not be proxied are logged and get 502 response
+func New(target *url.URL, l *logger.Logger) *httputil.ReverseProxy {
+	p := httputil.NewSingleHostReverseProxy(target)
+
+	director := p.Director
+	p.Director = func(r *http.Request) {
+		director(r)
+		r.Host = target.Host
+	}
+
+	p.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		l.Error().Err(err).Str("method", r.Method).Str("path", r.URL.Path).Msg("proxy request to " + target.String())
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	return p
+}
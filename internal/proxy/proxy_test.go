@@ -0,0 +1,41 @@
+package proxy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/logger"
+	"github.com/neotoolkit/dummy/internal/proxy"
+)
+
+func TestNew(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host + " " + r.URL.RequestURI()))
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL + "/api")
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	proxy.New(target, logger.NewLogger("ERROR")).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/users?limit=1", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, target.Host+" /api/users?limit=1", w.Body.String())
+}
+
+func TestNew_BadGateway(t *testing.T) {
+	backend := httptest.NewServer(http.NotFoundHandler())
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	backend.Close()
+
+	w := httptest.NewRecorder()
+	proxy.New(target, logger.NewLogger("ERROR")).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	require.Equal(t, http.StatusBadGateway, w.Code)
+}
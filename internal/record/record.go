@@ -0,0 +1,188 @@
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/stub"
+)
+
+// Recording is request to target and its response
+type Recording struct {
+	// Name is name of file without extension
+	Name     string   `json:"-"`
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+	// Violations are differences of response from specification
+	Violations []string `json:"violations,omitempty"`
+}
+
+// Request -.
+type Request struct {
+	Method string              `json:"method"`
+	Path   string              `json:"path"`
+	Query  map[string][]string `json:"query,omitempty"`
+	Header http.Header         `json:"header,omitempty"`
+	// Body is decoded JSON body, other bodies are kept as string
+	Body interface{} `json:"body,omitempty"`
+}
+
+// Response -.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	// Body is decoded JSON body, other bodies are kept as string
+	Body interface{} `json:"body,omitempty"`
+}
+
+// Check returns differences of recorded response from specification
+func Check(spec api.API, rec Recording) []string {
+	o, ok := spec.FindOperation(rec.Request.Path, rec.Request.Method)
+	if !ok {
+		return []string{"operation is not specified"}
+	}
+
+	for _, resp := range o.Responses {
+		if resp.StatusCode != rec.Response.Status {
+			continue
+		}
+
+		var violations []string
+
+		for _, h := range resp.Headers {
+			values := rec.Response.Header.Values(h.Name)
+			if len(values) == 0 {
+				if h.Required {
+					violations = append(violations, "header "+h.Name+" is missing")
+				}
+
+				continue
+			}
+
+			for _, e := range h.Validate(strings.Join(values, ",")) {
+				violations = append(violations, "header "+h.Name+" "+e.Error())
+			}
+		}
+
+		if resp.Schema != nil && rec.Response.Body != nil {
+			for _, e := range resp.Schema.Validate("", rec.Response.Body) {
+				violations = append(violations, "body "+e.Error())
+			}
+		}
+
+		return violations
+	}
+
+	return []string{fmt.Sprintf("status code %d is not specified", rec.Response.Status)}
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// Save writes recording to dir as JSON file with sequence number, method and path in name
+func Save(dir string, seq int, rec Recording) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%04d-%s%s", seq, rec.Request.Method, strings.TrimRight(unsafeChars.ReplaceAllString(rec.Request.Path, "-"), "-"))
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, name+".json")
+
+	return path, os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Load returns recordings from JSON files of dir in order of names
+func Load(dir string) ([]Recording, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+
+	recordings := make([]Recording, 0, len(paths))
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var rec Recording
+
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("recording %s: %w", path, err)
+		}
+
+		rec.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		recordings = append(recordings, rec)
+	}
+
+	return recordings, nil
+}
+
+// skippedHeaders are response headers which are set by server on replay
+var skippedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Date":              true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+}
+
+// Stub returns stub which replays recording for requests with the same method, path, query and JSON body
+func (rec Recording) Stub() stub.Stub {
+	s := stub.Stub{
+		ID: "recording-" + rec.Name,
+		Request: stub.Request{
+			Method: rec.Request.Method,
+			Path:   rec.Request.Path,
+		},
+		Response: stub.Response{
+			Status: rec.Response.Status,
+			Body:   rec.Response.Body,
+		},
+	}
+
+	for name, values := range rec.Request.Query {
+		if nil == s.Request.Query {
+			s.Request.Query = make(map[string]string, len(rec.Request.Query))
+		}
+
+		s.Request.Query[name] = values[0]
+	}
+
+	// stubs match only JSON bodies
+	if _, ok := rec.Request.Body.(string); !ok {
+		s.Request.Body = rec.Request.Body
+	}
+
+	for name, values := range rec.Response.Header {
+		if skippedHeaders[http.CanonicalHeaderKey(name)] || len(values) == 0 {
+			continue
+		}
+
+		if nil == s.Response.Header {
+			s.Response.Header = make(map[string]string, len(rec.Response.Header))
+		}
+
+		s.Response.Header[name] = values[0]
+	}
+
+	return s
+}
@@ -0,0 +1,214 @@
+package record_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/logger"
+	"github.com/neotoolkit/dummy/internal/record"
+	"github.com/neotoolkit/dummy/internal/stub"
+)
+
+var spec = api.API{
+	Operations: []api.Operation{
+		{
+			Method: http.MethodGet,
+			Path:   "/users/{userId}",
+			Responses: []api.Response{
+				{
+					StatusCode: http.StatusOK,
+					MediaType:  "application/json",
+					Headers: []api.Header{
+						{Name: "Etag", Required: true, Schema: api.StringSchema{}},
+						{Name: "X-Rate-Limit-Remaining", Schema: api.IntSchema{}},
+					},
+					Schema: api.ObjectSchema{
+						Required: []string{"id"},
+						Properties: map[string]api.Schema{
+							"id": api.StringSchema{},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording record.Recording
+		want      []string
+	}{
+		{
+			name: "valid",
+			recording: record.Recording{
+				Request: record.Request{Method: http.MethodGet, Path: "/users/1"},
+				Response: record.Response{
+					Status: http.StatusOK,
+					Header: http.Header{"Etag": {"v1"}, "X-Rate-Limit-Remaining": {"10"}},
+					Body:   map[string]interface{}{"id": "1"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "not specified operation",
+			recording: record.Recording{
+				Request:  record.Request{Method: http.MethodDelete, Path: "/users/1"},
+				Response: record.Response{Status: http.StatusNoContent},
+			},
+			want: []string{"operation is not specified"},
+		},
+		{
+			name: "not specified status code",
+			recording: record.Recording{
+				Request:  record.Request{Method: http.MethodGet, Path: "/users/1"},
+				Response: record.Response{Status: http.StatusNotFound},
+			},
+			want: []string{"status code 404 is not specified"},
+		},
+		{
+			name: "body",
+			recording: record.Recording{
+				Request: record.Request{Method: http.MethodGet, Path: "/users/1"},
+				Response: record.Response{
+					Status: http.StatusOK,
+					Header: http.Header{"Etag": {"v1"}},
+					Body:   map[string]interface{}{"name": "Elon"},
+				},
+			},
+			want: []string{"body /id: required property is missing"},
+		},
+		{
+			name: "headers",
+			recording: record.Recording{
+				Request:  record.Request{Method: http.MethodGet, Path: "/users/1"},
+				Response: record.Response{Status: http.StatusOK, Header: http.Header{"X-Rate-Limit-Remaining": {"many"}}},
+			},
+			want: []string{
+				"header Etag is missing",
+				"header X-Rate-Limit-Remaining /: must be integer, got string",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, record.Check(spec, tc.recording))
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+
+	rec := record.Recording{
+		Request: record.Request{
+			Method: http.MethodPost,
+			Path:   "/users/",
+			Query:  map[string][]string{"notify": {"true"}},
+			Body:   map[string]interface{}{"firstName": "Elon"},
+		},
+		Response: record.Response{
+			Status: http.StatusCreated,
+			Header: http.Header{"Content-Type": {"application/json"}, "Date": {"today"}},
+			Body:   map[string]interface{}{"id": "1"},
+		},
+	}
+
+	path, err := record.Save(dir, 7, rec)
+
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(path, "0007-POST-users.json"))
+
+	recordings, err := record.Load(dir)
+
+	require.NoError(t, err)
+	require.Len(t, recordings, 1)
+	require.Equal(t, stub.Stub{
+		ID: "recording-0007-POST-users",
+		Request: stub.Request{
+			Method: http.MethodPost,
+			Path:   "/users/",
+			Query:  map[string]string{"notify": "true"},
+			Body:   map[string]interface{}{"firstName": "Elon"},
+		},
+		Response: stub.Response{
+			Status: http.StatusCreated,
+			Header: map[string]string{"Content-Type": "application/json"},
+			Body:   map[string]interface{}{"id": "1"},
+		},
+	}, recordings[0].Stub())
+
+	_, err = record.Load(dir + "/missing")
+
+	require.Error(t, err)
+}
+
+func TestRecorder(t *testing.T) {
+	var authorization string
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", "v1")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	recorder := record.NewRecorder(target, dir, spec, logger.NewLogger("ERROR"))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Set("Cookie", "session=secret")
+	r.Header.Set("Proxy-Authorization", "Basic secret")
+	r.Header.Set("X-Request-Id", "42")
+
+	recorder.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"id": 1}`, w.Body.String())
+
+	recordings, err := record.Load(dir)
+
+	require.NoError(t, err)
+	require.Len(t, recordings, 1)
+	require.Equal(t, "0001-GET-users-1", recordings[0].Name)
+	require.Equal(t, map[string]interface{}{"id": float64(1)}, recordings[0].Response.Body)
+	require.Equal(t, []string{"body /id: must be string, got number"}, recordings[0].Violations)
+
+	// credentials are sent to backend and redacted in recording
+	require.Equal(t, "Bearer secret", authorization)
+	require.Equal(t, "session=secret", w.Header().Get("Set-Cookie"))
+
+	request := recordings[0].Request.Header
+	require.Equal(t, "REDACTED", request.Get("Authorization"))
+	require.Equal(t, "REDACTED", request.Get("Cookie"))
+	require.Equal(t, "REDACTED", request.Get("Proxy-Authorization"))
+	require.Equal(t, "42", request.Get("X-Request-Id"))
+	require.Equal(t, "REDACTED", recordings[0].Response.Header.Get("Set-Cookie"))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret")
+}
@@ -0,0 +1,151 @@
+package record
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"sync"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/journal"
+	"github.com/neotoolkit/dummy/internal/logger"
+	"github.com/neotoolkit/dummy/internal/proxy"
+)
+
+// Recorder proxies requests to target and saves request and response pairs to directory
+type Recorder struct {
+	Dir string
+	// Spec is used to report responses which differ from specification
+	Spec   api.API
+	Logger *logger.Logger
+
+	proxy *httputil.ReverseProxy
+	mu    sync.Mutex
+	seq   int
+}
+
+// NewRecorder returns a new instance of Recorder, recordings are numbered after recordings in dir
+func NewRecorder(target *url.URL, dir string, spec api.API, l *logger.Logger) *Recorder {
+	p := proxy.New(target, l)
+	director := p.Director
+	p.Director = func(r *http.Request) {
+		director(r)
+		// compressed responses would be recorded as binary bodies
+		r.Header.Del("Accept-Encoding")
+	}
+
+	existing, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+
+	return &Recorder{
+		Dir:    dir,
+		Spec:   spec,
+		Logger: l,
+		proxy:  p,
+		seq:    len(existing),
+	}
+}
+
+// redacted is value of credential headers in recordings
+const redacted = "REDACTED"
+
+// credentialHeaders are headers which are redacted in recordings, so recordings can be shared
+var credentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// redact returns copy of header with redacted credentials
+func redact(h http.Header) http.Header {
+	res := h.Clone()
+
+	for _, name := range credentialHeaders {
+		values := res.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		res.Del(name)
+
+		for range values {
+			res.Add(name, redacted)
+		}
+	}
+
+	return res
+}
+
+// responseRecorder copies response body which is written to client
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
+
+// ServeHTTP -.
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+
+	if r.Body != nil {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			rec.Logger.Error().Err(err).Msg("read request body")
+		}
+
+		body = data
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recording := Recording{
+		Request: Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: redact(r.Header),
+			Body:   journal.DecodeBody(body),
+		},
+	}
+
+	resp := &responseRecorder{ResponseWriter: w}
+
+	rec.proxy.ServeHTTP(resp, r)
+
+	recording.Response = Response{
+		Status: resp.status,
+		Header: redact(w.Header()),
+		Body:   journal.DecodeBody(resp.body.Bytes()),
+	}
+	recording.Violations = Check(rec.Spec, recording)
+
+	for _, v := range recording.Violations {
+		rec.Logger.Warn().Str("method", r.Method).Str("path", r.URL.Path).Msg("response differs from specification: " + v)
+	}
+
+	rec.mu.Lock()
+	rec.seq++
+	seq := rec.seq
+	rec.mu.Unlock()
+
+	path, err := Save(rec.Dir, seq, recording)
+	if err != nil {
+		rec.Logger.Error().Err(err).Msg("save recording")
+
+		return
+	}
+
+	rec.Logger.Debug().Str("file", path).Msg("request recorded")
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// AdminPath is prefix of admin endpoints, operations of specification under it are not served
+const AdminPath = "/__dummy"
+
+// AdminHandler serves admin endpoints
+func (s *Server) AdminHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	path := RemoveTrailingSlash(r.URL.Path)
+
+	switch {
+	case path == AdminPath+"/requests":
+		s.requestsHandler(w, r, false)
+	case path == AdminPath+"/requests/count":
+		s.requestsHandler(w, r, true)
+	case s.Handlers.Stubs == nil && strings.HasPrefix(path, AdminPath+"/stubs"):
+		w.WriteHeader(http.StatusNotFound)
+	case path == AdminPath+"/stubs":
+		s.stubsHandler(w, r)
+	case strings.HasPrefix(path, AdminPath+"/stubs/"):
+		s.stubHandler(w, r, strings.TrimPrefix(path, AdminPath+"/stubs/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// adminError writes error response of admin endpoint
+func (s *Server) adminError(w http.ResponseWriter, statusCode int, err error) {
+	s.writeJSON(w, statusCode, map[string]interface{}{
+		"error": err.Error(),
+	})
+}
+
+// methodNotAllowed writes 405 response with allowed methods
+func methodNotAllowed(w http.ResponseWriter, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	w.WriteHeader(http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"github.com/neotoolkit/dummy/internal/graphql"
+)
+
+// GraphQLPath is path of GraphQL endpoint
+const GraphQLPath = "/graphql"
+
+// GraphQLHandler executes GraphQL queries and mutations against GraphQL schema
+func (s *Server) GraphQLHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	params, err := graphQLParams(r)
+	if err != nil {
+		s.writeGraphQL(w, http.StatusBadRequest, &gql.Result{
+			Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())},
+		})
+
+		return
+	}
+
+	s.writeGraphQL(w, http.StatusOK, s.Handlers.API().GraphQL.Do(params))
+}
+
+func (s *Server) writeGraphQL(w http.ResponseWriter, statusCode int, result *gql.Result) {
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		s.Logger.Error().Err(err).Msg("write response")
+	}
+}
+
+func graphQLParams(r *http.Request) (graphql.Params, error) {
+	var params graphql.Params
+
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+
+		params.Query = q.Get("query")
+		params.OperationName = q.Get("operationName")
+
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &params.Variables); err != nil {
+				return graphql.Params{}, err
+			}
+		}
+
+		return params, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if mediaType == "application/graphql" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return graphql.Params{}, err
+		}
+
+		params.Query = string(body)
+
+		return params, nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return graphql.Params{}, err
+	}
+
+	return params, nil
+}
@@ -1,102 +1,301 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
-	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/encode"
+	"github.com/neotoolkit/dummy/internal/journal"
 	"github.com/neotoolkit/dummy/internal/logger"
+	"github.com/neotoolkit/dummy/internal/store"
+	"github.com/neotoolkit/dummy/internal/stub"
 )
 
 // Handlers -.
 type Handlers struct {
-	API    api.API
+	// spec holds api.API, it is replaced on specification reload
+	spec *atomic.Value
+	// store holds *store.Store, it is replaced with specification on reload
+	store  *atomic.Value
 	Logger *logger.Logger
+	// Seeder returns fakers which generate response values without example
+	Seeder *Seeder
+	// Journal keeps received requests, nil if journal is disabled
+	Journal *journal.Journal
+	// Stubs are served before operations of specification
+	Stubs *stub.Stubs
+	// Upstream serves requests which are not specified or are marked by x-dummy-proxy, nil if requests are not proxied
+	Upstream http.Handler
 }
 
 // NewHandlers returns a new instance of Handlers
-func NewHandlers(api api.API, l *logger.Logger) Handlers {
-	return Handlers{
-		API:    api,
-		Logger: l,
+func NewHandlers(a api.API, l *logger.Logger) Handlers {
+	h := Handlers{
+		spec:    &atomic.Value{},
+		store:   &atomic.Value{},
+		Logger:  l,
+		Seeder:  NewRandomSeeder(),
+		Journal: journal.NewJournal(journal.DefaultLimit),
+		Stubs:   stub.NewStubs(),
 	}
+
+	h.SetAPI(a)
+	h.SetStore(nil)
+
+	return h
+}
+
+// API returns current specification
+func (h Handlers) API() api.API {
+	return h.spec.Load().(api.API)
+}
+
+// SetAPI replaces specification, requests in progress are served by the previous one
+func (h Handlers) SetAPI(a api.API) {
+	h.spec.Store(a)
+}
+
+// Store returns resource store for stateful mode, nil if stateful mode is disabled
+func (h Handlers) Store() *store.Store {
+	return h.store.Load().(*store.Store)
+}
+
+// SetStore replaces resource store, nil disables stateful mode, requests in progress are served by the previous one
+func (h Handlers) SetStore(s *store.Store) {
+	h.store.Store(s)
 }
 
 // Handler -.
 func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
-	if setStatusCode(w, r.Header.Get("X-Set-Status-Code")) {
+	// specification is loaded once, so request is served by one version of it during reload
+	spec, st := s.Handlers.API(), s.Handlers.Store()
+
+	if s.serveStub(w, r) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	if spec.GraphQL != nil && RemoveFragment(r.URL.Path) == GraphQLPath {
+		s.GraphQLHandler(w, r)
+
+		return
+	}
 
 	path := RemoveFragment(r.URL.Path)
 
-	response, ok, err := s.Handlers.Get(path, r.Method, r.Body)
-	if ok {
-		if _, ok := err.(*json.SyntaxError); ok || errors.Is(err, api.ErrEmptyRequireField) {
-			w.WriteHeader(http.StatusBadRequest)
+	if s.Handlers.Upstream != nil {
+		if o, ok := spec.FindOperation(path, r.Method); !ok || o.Proxy {
+			s.Handlers.Upstream.ServeHTTP(w, r)
 
 			return
 		}
+	}
 
-		w.WriteHeader(response.StatusCode)
-		resp := response.ExampleValue(r.Header.Get("X-Example"))
+	pref, err := parsePreference(r)
+	if err != nil {
+		s.badRequest(w, err)
 
-		if nil == resp {
-			return
+		return
+	}
+
+	f, err := s.Handlers.Seeder.Faker(r)
+	if err != nil {
+		s.badRequest(w, err)
+
+		return
+	}
+
+	// requested response is served instead of objects of store
+	if st != nil && !pref.selected() && s.stateful(w, r, spec, st, path, f) {
+		return
+	}
+
+	response, ok, err := s.Handlers.Get(spec, path, r, pref.code)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	if s.requestError(w, err) {
+		return
+	}
+
+	setHeaders(w, response.Headers, func(h api.Header) string {
+		return pref.header(h, f)
+	})
+
+	s.writeResponse(w, response, pref.body(response, f))
+}
+
+// setHeaders sets headers of response which have value
+func setHeaders(w http.ResponseWriter, headers []api.Header, value func(api.Header) string) {
+	for _, h := range headers {
+		if v := value(h); v != "" {
+			w.Header().Set(h.Name, v)
 		}
+	}
+}
+
+// requestError writes response for error of request and returns true if err is such error:
+// 400 for invalid request, 405 for not specified method of path, 406 for not acceptable response
+// and 415 for not supported request body
+func (s *Server) requestError(w http.ResponseWriter, err error) bool {
+	var (
+		notAllowed    *api.MethodNotAllowedError
+		notAcceptable *api.NotAcceptableError
+		unsupported   *api.UnsupportedMediaTypeError
+	)
+
+	switch {
+	case isBadRequest(err):
+		s.badRequest(w, err)
+	case errors.As(err, &notAllowed):
+		w.Header().Set("Allow", strings.Join(notAllowed.Allow, ", "))
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	case errors.As(err, &notAcceptable):
+		s.headerError(w, http.StatusNotAcceptable, "Accept", notAcceptable)
+	case errors.As(err, &unsupported):
+		s.headerError(w, http.StatusUnsupportedMediaType, "Content-Type", unsupported)
+	default:
+		return false
+	}
+
+	return true
+}
 
-		bytes, err := json.Marshal(resp)
-		if err != nil {
-			s.Logger.Error().Err(err).Msg("serialize response")
+// headerError writes response with violation of request header
+func (s *Server) headerError(w http.ResponseWriter, statusCode int, header string, err error) {
+	s.writeJSON(w, statusCode, map[string]interface{}{
+		"errors": api.ValidationErrors{{
+			In:        "header",
+			Parameter: header,
+			Message:   err.Error(),
+		}},
+	})
+}
+
+// writeResponse writes body in format of media type of response
+// Content-Type is set only if body is written, so it is omitted in responses without body, e.g. 204
+func (s *Server) writeResponse(w http.ResponseWriter, response api.Response, body interface{}) {
+	var buf bytes.Buffer
+
+	if body != nil {
+		if err := encode.Encode(&buf, response.MediaType, response.Schema, body); err != nil {
+			s.Logger.Error().Err(err).Msg("encode response")
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
 		}
+	}
 
-		_, err = w.Write(bytes)
-		if err != nil {
-			s.Logger.Error().Err(err).Msg("write response")
+	// length is set explicitly, so response to HEAD request has length of body which is discarded
+	if buf.Len() > 0 {
+		mediaType := response.MediaType
+		if mediaType == "" {
+			mediaType = "application/json"
 		}
 
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	}
+
+	w.WriteHeader(response.StatusCode)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		s.Logger.Error().Err(err).Msg("write response")
+	}
+}
+
+func isBadRequest(err error) bool {
+	if _, ok := err.(*json.SyntaxError); ok {
+		return true
+	}
+
+	_, ok := err.(api.ValidationErrors)
+
+	return ok
+}
+
+// badRequest writes 400 response, violations of request body schema are listed in response body
+func (s *Server) badRequest(w http.ResponseWriter, err error) {
+	errs, ok := err.(api.ValidationErrors)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"errors": errs,
+	})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, statusCode int, resp interface{}) {
+	if nil == resp {
+		w.WriteHeader(statusCode)
+
 		return
 	}
 
-	w.WriteHeader(http.StatusNotFound)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	bytes, err := json.Marshal(resp)
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("serialize response")
+	}
+
+	_, err = w.Write(bytes)
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("write response")
+	}
 }
 
-// Get -.
-func (h Handlers) Get(path, method string, body io.ReadCloser) (api.Response, bool, error) {
-	response, err := h.API.FindResponse(api.FindResponseParams{
-		Path:   path,
-		Method: method,
-		Body:   body,
+// Get returns response of operation with status code, zero status code is default response of operation
+func (h Handlers) Get(spec api.API, path string, r *http.Request, statusCode int) (api.Response, bool, error) {
+	response, err := spec.FindResponse(api.FindResponseParams{
+		Path:       path,
+		Method:     r.Method,
+		Body:       r.Body,
+		Accept:     r.Header.Get("Accept"),
+		Query:      r.URL.Query(),
+		Header:     r.Header,
+		StatusCode: statusCode,
 	})
 	if err != nil {
-		if errors.Is(err, api.ErrEmptyRequireField) {
+		if _, ok := err.(*json.SyntaxError); ok {
 			return api.Response{}, true, err
 		}
 
-		if _, ok := err.(*json.SyntaxError); ok {
+		if _, ok := err.(api.ValidationErrors); ok {
 			return api.Response{}, true, err
 		}
 
-		return api.Response{}, false, err
-	}
+		var notAcceptable *api.NotAcceptableError
+		if errors.As(err, &notAcceptable) {
+			return api.Response{}, true, err
+		}
 
-	return response, true, nil
-}
+		var unsupported *api.UnsupportedMediaTypeError
+		if errors.As(err, &unsupported) {
+			return api.Response{}, true, err
+		}
 
-func setStatusCode(w http.ResponseWriter, statusCode string) bool {
-	switch statusCode {
-	case "500":
-		w.WriteHeader(http.StatusInternalServerError)
+		var notAllowed *api.MethodNotAllowedError
+		if errors.As(err, &notAllowed) {
+			return api.Response{}, true, err
+		}
 
-		return true
-	default:
-		return false
+		return api.Response{}, false, err
 	}
+
+	return response, true, nil
 }
 
 // RemoveTrailingSlash returns path without trailing slash
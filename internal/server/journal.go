@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/neotoolkit/dummy/internal/journal"
+)
+
+// statusRecorder keeps status code of response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.ResponseWriter.Write(b)
+}
+
+// journaled adds requests served by next handler to journal
+func (s *Server) journaled(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j := s.Handlers.Journal
+		if nil == j {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		var body []byte
+
+		if r.Body != nil {
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				s.Logger.Error().Err(err).Msg("read request body")
+			}
+
+			body = data
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		entry := journal.Entry{
+			Time:   time.Now(),
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   journal.DecodeBody(body),
+		}
+
+		if o, ok := s.Handlers.API().FindOperation(RemoveFragment(r.URL.Path), r.Method); ok {
+			entry.Operation = o.Method + " " + o.Path
+		}
+
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		entry.Status = rec.status
+		if entry.Status == 0 {
+			entry.Status = http.StatusOK
+		}
+
+		j.Add(entry)
+	})
+}
+
+// requestsHandler serves journal: GET lists requests, GET count returns number of requests and DELETE resets journal
+func (s *Server) requestsHandler(w http.ResponseWriter, r *http.Request, count bool) {
+	j := s.Handlers.Journal
+	if nil == j {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodDelete && !count:
+		j.Reset()
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	case r.Method != http.MethodGet && count:
+		methodNotAllowed(w, http.MethodGet)
+
+		return
+	case r.Method != http.MethodGet:
+		methodNotAllowed(w, http.MethodGet, http.MethodDelete)
+
+		return
+	}
+
+	f, err := journal.ParseFilter(r.URL.Query())
+	if err != nil {
+		s.adminError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	if count {
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"count": j.Count(f),
+		})
+
+		return
+	}
+
+	entries := j.Entries(f)
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":    len(entries),
+		"requests": entries,
+	})
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+// Query parameters which select response like Prefer header
+const (
+	CodeQuery    = "__code"
+	ExampleQuery = "__example"
+	DynamicQuery = "__dynamic"
+)
+
+// preference is response which is requested by client
+type preference struct {
+	// code is status code of response, zero is default response
+	code int
+	// example is name of example
+	example string
+	// dynamic is true if values are generated even if there is example, false if values are not generated, nil is default
+	dynamic *bool
+}
+
+// selected returns true if client requested response which may differ from regular response of operation
+func (p preference) selected() bool {
+	return p.code != 0 || p.example != "" || p.dynamic != nil
+}
+
+// preferenceValue is value of preference with its source, source is header or query parameter which sets value
+type preferenceValue struct {
+	name  string
+	value string
+	in    string
+	from  string
+}
+
+// parsePreference returns response requested by headers and query parameters, later ones take precedence:
+// X-Set-Status-Code and X-Example headers, Prefer header like "code=404, example=notFound, dynamic=true" and __code, __example and __dynamic query parameters
+func parsePreference(r *http.Request) (preference, error) {
+	var p preference
+
+	values := make([]preferenceValue, 0)
+
+	if v := r.Header.Get("X-Set-Status-Code"); v != "" {
+		values = append(values, preferenceValue{name: "code", value: v, in: api.InHeader, from: "X-Set-Status-Code"})
+	}
+
+	if v := r.Header.Get("X-Example"); v != "" {
+		values = append(values, preferenceValue{name: "example", value: v, in: api.InHeader, from: "X-Example"})
+	}
+
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			// parameters of preference are ignored
+			pref = strings.Split(pref, ";")[0]
+
+			kv := strings.SplitN(pref, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+
+			values = append(values, preferenceValue{
+				name:  strings.ToLower(strings.TrimSpace(kv[0])),
+				value: strings.Trim(strings.TrimSpace(kv[1]), `"`),
+				in:    api.InHeader,
+				from:  "Prefer",
+			})
+		}
+	}
+
+	query := r.URL.Query()
+
+	for _, kv := range [][2]string{{"code", CodeQuery}, {"example", ExampleQuery}, {"dynamic", DynamicQuery}} {
+		if v := query.Get(kv[1]); v != "" {
+			values = append(values, preferenceValue{name: kv[0], value: v, in: api.InQuery, from: kv[1]})
+		}
+	}
+
+	for _, v := range values {
+		switch v.name {
+		case "code":
+			code, err := strconv.Atoi(v.value)
+			if err != nil || code < 100 || code > 599 {
+				return preference{}, v.error("code must be integer between 100 and 599, got " + v.value)
+			}
+
+			p.code = code
+		case "example":
+			p.example = v.value
+		case "dynamic":
+			dynamic, err := strconv.ParseBool(v.value)
+			if err != nil {
+				return preference{}, v.error("dynamic must be boolean, got " + v.value)
+			}
+
+			p.dynamic = &dynamic
+		}
+	}
+
+	return p, nil
+}
+
+// error returns validation error of header or query parameter which sets value
+func (v preferenceValue) error(message string) error {
+	return api.ValidationErrors{{
+		In:        v.in,
+		Parameter: v.from,
+		Message:   message,
+	}}
+}
+
+// body returns body of response: named example, value generated by faker if dynamic is true and static example if dynamic is false
+// Values without example are generated by faker by default
+func (p preference) body(response api.Response, f faker.Faker) interface{} {
+	switch {
+	case nil == p.dynamic:
+		return response.DynamicValue(p.example, f)
+	case *p.dynamic:
+		return response.GeneratedValue(f)
+	default:
+		return response.ExampleValue(p.example)
+	}
+}
+
+// header returns value of response header like body of response
+func (p preference) header(h api.Header, f faker.Faker) string {
+	switch {
+	case nil == p.dynamic:
+		return h.DynamicValue(f)
+	case *p.dynamic:
+		return h.GeneratedValue(f)
+	default:
+		return h.ExampleValue()
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+// SeedHeader is request header with seed of generated response values
+const SeedHeader = "X-Dummy-Seed"
+
+// Seeder returns fakers for responses, it is safe for concurrent use
+type Seeder struct {
+	mu    sync.Mutex
+	rand  *rand.Rand
+	faker faker.Faker
+}
+
+// NewSeeder returns a new instance of Seeder, the same seed gives the same sequence of fakers
+func NewSeeder(seed int64) *Seeder {
+	return &Seeder{
+		rand:  rand.New(rand.NewSource(seed)),
+		faker: faker.NewFaker(),
+	}
+}
+
+// NewRandomSeeder returns a new instance of Seeder seeded by current time
+func NewRandomSeeder() *Seeder {
+	return NewSeeder(time.Now().UnixNano())
+}
+
+// Faker returns faker for request, it is seeded by SeedHeader if request has it
+func (s *Seeder) Faker(r *http.Request) (faker.Faker, error) {
+	if value := r.Header.Get(SeedHeader); value != "" {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return faker.Faker{}, api.ValidationErrors{
+				{In: api.InHeader, Parameter: SeedHeader, Message: "must be integer, got " + value},
+			}
+		}
+
+		return s.seeded(seed), nil
+	}
+
+	s.mu.Lock()
+	seed := s.rand.Int63()
+	s.mu.Unlock()
+
+	return s.seeded(seed), nil
+}
+
+// seeded returns copy of faker with own generator, faker is not safe for concurrent use
+func (s *Seeder) seeded(seed int64) faker.Faker {
+	f := s.faker
+	f.Generator = rand.New(rand.NewSource(seed))
+
+	return f
+}
@@ -26,22 +26,35 @@ func NewServer(config config.Server, l *logger.Logger, h Handlers) *Server {
 	}
 }
 
-// Run -.
-func (s *Server) Run() error {
+// Routes returns handler of specification operations and admin endpoints
+func (s *Server) Routes() http.Handler {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/", s.Handler)
+	mux.Handle("/", s.journaled(http.HandlerFunc(s.Handler)))
+	mux.HandleFunc(AdminPath+"/", s.AdminHandler)
 
-	handler := middleware.Logging(mux, s.Logger)
+	return mux
+}
+
+// Run -.
+func (s *Server) Run() error {
+	handler := middleware.Logging(middleware.CORS(middleware.Latency(s.Routes(), s.Config.Latency), s.Config.CORS), s.Logger)
 
 	s.Server = &http.Server{
-		Addr:    ":" + s.Config.Port,
+		Addr:    s.Config.Addr(),
 		Handler: handler,
 	}
 
 	s.Logger.Info().Msgf("Running mock server on %s port", s.Config.Port)
 
-	err := s.Server.ListenAndServe()
+	var err error
+
+	if s.Config.TLS.Enabled() {
+		err = s.Server.ListenAndServeTLS(s.Config.TLS.Cert, s.Config.TLS.Key)
+	} else {
+		err = s.Server.ListenAndServe()
+	}
+
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/store"
+)
+
+// stateful handles request to resource with store, it returns false if request is not resource operation
+func (s *Server) stateful(w http.ResponseWriter, r *http.Request, spec api.API, st *store.Store, path string, f faker.Faker) bool {
+	resource, collection, id, ok := st.Match(path)
+	if !ok {
+		return false
+	}
+
+	// operation of literal path like /users/me is not resource operation even if path matches resource
+	operation, ok := spec.FindOperation(path, r.Method)
+	if !ok || (operation.Path != resource.Item && operation.Path != resource.Collection) {
+		return false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return true
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	_, _, err = s.Handlers.Get(spec, path, r, 0)
+
+	// body is restored for the case when request falls back to stateless handling
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if s.requestError(w, err) {
+		return true
+	}
+
+	// HEAD request is served as GET request, body is discarded by HTTP server
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+
+	var obj map[string]interface{}
+
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		decoded, err := operation.DecodeBody(r.Header.Get("Content-Type"), body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return true
+		}
+
+		obj, ok = decoded.(map[string]interface{})
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return true
+		}
+	}
+
+	response := successResponse(operation)
+
+	// headers of specification are served with objects of store
+	writeHeaders := func() {
+		setHeaders(w, response.Headers, func(h api.Header) string {
+			return h.DynamicValue(f)
+		})
+	}
+
+	switch {
+	case id == "" && method == http.MethodGet:
+		writeHeaders()
+		s.writeJSON(w, response.StatusCode, st.List(collection))
+	case id == "" && method == http.MethodPost:
+		item := exampleObject(response, f)
+		delete(item, resource.IDField)
+
+		for k, v := range obj {
+			item[k] = v
+		}
+
+		writeHeaders()
+		s.writeJSON(w, response.StatusCode, st.Create(collection, resource, item))
+	case id != "" && method == http.MethodGet:
+		item, ok := st.Get(collection, id)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return true
+		}
+
+		writeHeaders()
+		s.writeJSON(w, response.StatusCode, item)
+	case id != "" && method == http.MethodPut:
+		item, _ := st.Put(collection, resource, id, obj)
+
+		writeHeaders()
+		s.writeJSON(w, response.StatusCode, item)
+	case id != "" && method == http.MethodPatch:
+		item, ok := st.Patch(collection, resource, id, obj)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return true
+		}
+
+		writeHeaders()
+		s.writeJSON(w, response.StatusCode, item)
+	case id != "" && method == http.MethodDelete:
+		if !st.Delete(collection, id) {
+			w.WriteHeader(http.StatusNotFound)
+
+			return true
+		}
+
+		writeHeaders()
+		w.WriteHeader(response.StatusCode)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// successResponse returns response with the lowest 2xx status code of operation
+func successResponse(o api.Operation) api.Response {
+	res := api.Response{StatusCode: http.StatusOK}
+	found := false
+
+	for _, r := range o.Responses {
+		if r.StatusCode < http.StatusOK || r.StatusCode >= http.StatusMultipleChoices {
+			continue
+		}
+
+		if !found || r.StatusCode < res.StatusCode {
+			res = r
+			found = true
+		}
+	}
+
+	return res
+}
+
+func exampleObject(r api.Response, f faker.Faker) map[string]interface{} {
+	obj, ok := r.DynamicValue("", f).(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+
+	res := make(map[string]interface{}, len(obj))
+
+	for k, v := range obj {
+		res[k] = v
+	}
+
+	return res
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/stub"
+)
+
+// serveStub writes response of stub which matches request, false is returned if there is no such stub
+func (s *Server) serveStub(w http.ResponseWriter, r *http.Request) bool {
+	stubs := s.Handlers.Stubs
+	if nil == stubs || stubs.Len() == 0 {
+		return false
+	}
+
+	var body interface{}
+
+	if r.Body != nil {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.Logger.Error().Err(err).Msg("read request body")
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		// stubs with body match only JSON bodies
+		_ = json.Unmarshal(data, &body)
+	}
+
+	st, ok := stubs.Find(r, body)
+	if !ok {
+		return false
+	}
+
+	for name, value := range st.Response.Header {
+		w.Header().Set(name, value)
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	text, isText := st.Response.Body.(string)
+	if isText && !strings.Contains(w.Header().Get("Content-Type"), "json") {
+		w.WriteHeader(st.Response.Status)
+
+		if _, err := io.WriteString(w, text); err != nil {
+			s.Logger.Error().Err(err).Msg("write response")
+		}
+
+		return true
+	}
+
+	s.writeJSON(w, st.Response.Status, st.Response.Body)
+
+	return true
+}
+
+// stubsHandler serves stubs: POST adds stub, GET lists stubs and DELETE removes all stubs
+func (s *Server) stubsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"stubs": s.Handlers.Stubs.List(),
+		})
+	case http.MethodPost:
+		var st stub.Stub
+
+		if err := json.NewDecoder(r.Body).Decode(&st); err != nil {
+			s.adminError(w, http.StatusBadRequest, err)
+
+			return
+		}
+
+		st, err := s.Handlers.Stubs.Add(st)
+		if err != nil {
+			s.adminError(w, http.StatusBadRequest, err)
+
+			return
+		}
+
+		s.writeJSON(w, http.StatusCreated, st)
+	case http.MethodDelete:
+		s.Handlers.Stubs.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost, http.MethodDelete)
+	}
+}
+
+// stubHandler serves stub by identifier: GET returns stub and DELETE removes it
+func (s *Server) stubHandler(w http.ResponseWriter, r *http.Request, id string) {
+	switch r.Method {
+	case http.MethodGet:
+		st, ok := s.Handlers.Stubs.Get(id)
+		if !ok {
+			s.adminError(w, http.StatusNotFound, errors.New("unknown stub "+id))
+
+			return
+		}
+
+		s.writeJSON(w, http.StatusOK, st)
+	case http.MethodDelete:
+		if !s.Handlers.Stubs.Delete(id) {
+			s.adminError(w, http.StatusNotFound, errors.New("unknown stub "+id))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodDelete)
+	}
+}
@@ -0,0 +1,488 @@
+package store
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/neotoolkit/faker"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+// Resource is collection of objects described by collection and item paths, e.g. /users and /users/{userId}
+type Resource struct {
+	// Collection is path template of collection
+	Collection string
+	// Item is path template of collection item
+	Item string
+	// Param is name of path parameter which identifies item
+	Param string
+	// IDField is name of object field which contains item identifier
+	IDField string
+	// NumericID is true if identifier is integer or number
+	NumericID bool
+}
+
+// Store is concurrency-safe in-memory storage of resource objects
+type Store struct {
+	// Resources are indexed by NewStore
+	Resources []Resource
+
+	// router finds item and collection paths of resources with the same precedence as API
+	router  *api.Router
+	matches map[string]match
+
+	mu          sync.RWMutex
+	faker       faker.Faker
+	collections map[string]*collection
+}
+
+// match is resource of path template, item is true for item path
+type match struct {
+	resource Resource
+	item     bool
+}
+
+type collection struct {
+	ids   []string
+	items map[string]map[string]interface{}
+}
+
+// NewStore returns a new instance of Store with resources grouped from api operations and seeded from examples
+func NewStore(a api.API) *Store {
+	s := &Store{
+		Resources:   Resources(a),
+		faker:       faker.NewFaker(),
+		collections: make(map[string]*collection),
+	}
+
+	s.index()
+	s.seed(a)
+
+	return s
+}
+
+// Resources groups api operations to resources by path shape
+func Resources(a api.API) []Resource {
+	paths := make(map[string]bool, len(a.Operations))
+
+	for _, op := range a.Operations {
+		paths[op.Path] = true
+	}
+
+	resources := make([]Resource, 0)
+
+	for path := range paths {
+		i := strings.LastIndex(path, "/")
+		if i < 0 {
+			continue
+		}
+
+		param, ok := pathParam(path[i+1:])
+		if !ok {
+			continue
+		}
+
+		r := Resource{
+			Collection: path[:i],
+			Item:       path,
+			Param:      param,
+			IDField:    "id",
+		}
+
+		if field, schema, ok := idField(a, r); ok {
+			r.IDField = field
+
+			switch api.Unwrap(schema).(type) {
+			case api.IntSchema, api.FloatSchema:
+				r.NumericID = true
+			}
+		}
+
+		resources = append(resources, r)
+	}
+
+	// longer templates are more specific, e.g. /users/{userId}/posts before /users/{userId}
+	sort.Slice(resources, func(i, j int) bool {
+		if len(resources[i].Item) != len(resources[j].Item) {
+			return len(resources[i].Item) > len(resources[j].Item)
+		}
+
+		return resources[i].Item < resources[j].Item
+	})
+
+	return resources
+}
+
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+
+	return "", false
+}
+
+// idField returns object field which contains identifier, it is "id" or field named as path parameter
+func idField(a api.API, r Resource) (string, api.Schema, bool) {
+	for _, op := range a.Operations {
+		if op.Path != r.Item || op.Method != http.MethodGet {
+			continue
+		}
+
+		for _, resp := range op.Responses {
+			if schema, ok := property(resp.Schema, "id"); ok {
+				return "id", schema, true
+			}
+
+			if schema, ok := property(resp.Schema, r.Param); ok {
+				return r.Param, schema, true
+			}
+		}
+	}
+
+	return "", nil, false
+}
+
+// index builds router of item and collection paths of resources, item wins if path is item and collection
+func (s *Store) index() {
+	s.matches = make(map[string]match, 2*len(s.Resources))
+	operations := make([]api.Operation, 0, 2*len(s.Resources))
+
+	for _, r := range s.Resources {
+		if _, ok := s.matches[r.Item]; !ok {
+			s.matches[r.Item] = match{resource: r, item: true}
+		}
+
+		operations = append(operations, api.Operation{Method: http.MethodGet, Path: r.Item})
+	}
+
+	for _, r := range s.Resources {
+		if _, ok := s.matches[r.Collection]; !ok {
+			s.matches[r.Collection] = match{resource: r}
+		}
+
+		operations = append(operations, api.Operation{Method: http.MethodGet, Path: r.Collection})
+	}
+
+	s.router = api.NewRouter(operations)
+}
+
+// property returns schema of object property, nullable objects and allOf members are unwrapped
+func property(s api.Schema, name string) (api.Schema, bool) {
+	switch s := api.Unwrap(s).(type) {
+	case api.ObjectSchema:
+		schema, ok := s.Properties[name]
+
+		return schema, ok
+	case api.AllOfSchema:
+		for _, member := range s.Schemas {
+			if schema, ok := property(member, name); ok {
+				return schema, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Match returns resource for request path, concrete collection path and item identifier
+// Identifier is empty for collection path
+func (s *Store) Match(path string) (Resource, string, string, bool) {
+	route, err := s.router.Find(path, http.MethodGet)
+	if err != nil {
+		return Resource{}, "", "", false
+	}
+
+	m := s.matches[route.Operation.Path]
+	if !m.item {
+		return m.resource, path, "", true
+	}
+
+	i := strings.LastIndex(path, "/")
+
+	return m.resource, path[:i], path[i+1:], true
+}
+
+// List returns all objects of collection in insertion order
+func (s *Store) List(collection string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return []interface{}{}
+	}
+
+	list := make([]interface{}, 0, len(c.ids))
+
+	for _, id := range c.ids {
+		list = append(list, copyObject(c.items[id]))
+	}
+
+	return list
+}
+
+// Get returns object of collection by identifier
+func (s *Store) Get(collection, id string) (map[string]interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := c.items[id]
+	if !ok {
+		return nil, false
+	}
+
+	return copyObject(item), true
+}
+
+// Create adds object to collection, identifier is generated if object has no one
+func (s *Store) Create(collection string, r Resource, item map[string]interface{}) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item = copyObject(item)
+
+	c := s.collection(collection)
+
+	id, ok := item[r.IDField]
+	if !ok || nil == id {
+		id = c.nextID(r.NumericID, s.faker)
+		item[r.IDField] = id
+	}
+
+	c.put(key(id), item)
+
+	return copyObject(item)
+}
+
+// Put replaces object of collection by identifier, it returns true if object was created
+func (s *Store) Put(collection string, r Resource, id string, item map[string]interface{}) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item = copyObject(item)
+
+	c := s.collection(collection)
+
+	old, exists := c.items[id]
+
+	switch {
+	case exists:
+		item[r.IDField] = old[r.IDField]
+	case r.NumericID:
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			item[r.IDField] = id
+		} else {
+			item[r.IDField] = n
+		}
+	default:
+		item[r.IDField] = id
+	}
+
+	c.put(id, item)
+
+	return copyObject(item), !exists
+}
+
+// Patch merges patch to object of collection by identifier according to JSON Merge Patch
+func (s *Store) Patch(collection string, r Resource, id string, patch map[string]interface{}) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := c.items[id]
+	if !ok {
+		return nil, false
+	}
+
+	merged := merge(item, patch)
+	merged[r.IDField] = item[r.IDField]
+
+	c.items[id] = merged
+
+	return copyObject(merged), true
+}
+
+// Delete removes object of collection by identifier, it returns false if object not found
+func (s *Store) Delete(collection, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return false
+	}
+
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+
+	delete(c.items, id)
+
+	for i, v := range c.ids {
+		if v == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+
+			break
+		}
+	}
+
+	return true
+}
+
+func (s *Store) collection(name string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = &collection{
+			items: make(map[string]map[string]interface{}),
+		}
+		s.collections[name] = c
+	}
+
+	return c
+}
+
+func (c *collection) put(id string, item map[string]interface{}) {
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+
+	c.items[id] = item
+}
+
+// nextID returns next integer identifier for numeric identifiers, otherwise UUID
+func (c *collection) nextID(numeric bool, f faker.Faker) interface{} {
+	if !numeric {
+		return f.UUID().V4()
+	}
+
+	var last int64
+
+	for _, id := range c.ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err == nil && n > last {
+			last = n
+		}
+	}
+
+	return last + 1
+}
+
+func (s *Store) seed(a api.API) {
+	for _, r := range s.Resources {
+		// only top-level collections can be seeded, nested collections depend on parent identifier
+		if strings.Contains(r.Collection, "{") {
+			continue
+		}
+
+		for _, op := range a.Operations {
+			if op.Method != http.MethodGet {
+				continue
+			}
+
+			switch op.Path {
+			case r.Collection:
+				for _, resp := range op.Responses {
+					for _, item := range objects(resp.Example) {
+						s.seedItem(r, item)
+					}
+				}
+			case r.Item:
+				for _, resp := range op.Responses {
+					if item, ok := resp.Example.(map[string]interface{}); ok {
+						s.seedItem(r, item)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (s *Store) seedItem(r Resource, item map[string]interface{}) {
+	id, ok := item[r.IDField]
+	if !ok {
+		return
+	}
+
+	s.collection(r.Collection).put(key(id), copyObject(item))
+}
+
+func objects(example interface{}) []map[string]interface{} {
+	switch e := example.(type) {
+	case []map[string]interface{}:
+		return e
+	case []interface{}:
+		list := make([]map[string]interface{}, 0, len(e))
+
+		for _, v := range e {
+			if obj, ok := v.(map[string]interface{}); ok {
+				list = append(list, obj)
+			}
+		}
+
+		return list
+	}
+
+	return nil
+}
+
+func key(id interface{}) string {
+	switch v := id.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func merge(dst, patch map[string]interface{}) map[string]interface{} {
+	res := copyObject(dst)
+
+	for k, v := range patch {
+		if nil == v {
+			delete(res, k)
+
+			continue
+		}
+
+		p, ok := v.(map[string]interface{})
+		if !ok {
+			res[k] = v
+
+			continue
+		}
+
+		d, ok := res[k].(map[string]interface{})
+		if !ok {
+			d = map[string]interface{}{}
+		}
+
+		res[k] = merge(d, p)
+	}
+
+	return res
+}
+
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(obj))
+
+	for k, v := range obj {
+		res[k] = v
+	}
+
+	return res
+}
@@ -0,0 +1,214 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/store"
+)
+
+func testAPI() api.API {
+	user := api.ObjectSchema{
+		Properties: map[string]api.Schema{
+			"id":   api.IntSchema{},
+			"name": api.StringSchema{},
+		},
+	}
+
+	return api.API{
+		Operations: []api.Operation{
+			{
+				Method: "GET",
+				Path:   "/users",
+				Responses: []api.Response{
+					{
+						StatusCode: 200,
+						Schema:     api.ArraySchema{Type: user},
+						Example: []map[string]interface{}{
+							{"id": uint64(1), "name": "Elon"},
+							{"id": uint64(2), "name": "Sergey"},
+						},
+					},
+				},
+			},
+			{
+				Method:    "POST",
+				Path:      "/users",
+				Responses: []api.Response{{StatusCode: 201, Schema: user}},
+			},
+			{
+				Method:    "GET",
+				Path:      "/users/{userId}",
+				Responses: []api.Response{{StatusCode: 200, Schema: user}},
+			},
+			{
+				Method:    "GET",
+				Path:      "/users/{userId}/posts/{postId}",
+				Responses: []api.Response{{StatusCode: 200}},
+			},
+		},
+	}
+}
+
+func TestResources(t *testing.T) {
+	got := store.Resources(testAPI())
+
+	require.Equal(t, []store.Resource{
+		{
+			Collection: "/users/{userId}/posts",
+			Item:       "/users/{userId}/posts/{postId}",
+			Param:      "postId",
+			IDField:    "id",
+		},
+		{
+			Collection: "/users",
+			Item:       "/users/{userId}",
+			Param:      "userId",
+			IDField:    "id",
+			NumericID:  true,
+		},
+	}, got)
+}
+
+func TestResources_WrappedSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema api.Schema
+	}{
+		{
+			name:   "nullable",
+			schema: api.NullableSchema{Schema: api.ObjectSchema{Properties: map[string]api.Schema{"id": api.IntSchema{}}}},
+		},
+		{
+			name: "allOf",
+			schema: api.AllOfSchema{Schemas: []api.Schema{
+				api.ObjectSchema{Properties: map[string]api.Schema{"name": api.StringSchema{}}},
+				api.NullableSchema{Schema: api.ObjectSchema{Properties: map[string]api.Schema{"orderId": api.NullableSchema{Schema: api.IntSchema{}}}}},
+				api.StringSchema{},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := store.Resources(api.API{
+				Operations: []api.Operation{
+					{
+						Method:    "GET",
+						Path:      "/orders/{orderId}",
+						Responses: []api.Response{{StatusCode: 200, Schema: tc.schema}},
+					},
+				},
+			})
+
+			require.Len(t, got, 1)
+			require.True(t, got[0].NumericID)
+		})
+	}
+}
+
+func TestStore_Match(t *testing.T) {
+	s := store.NewStore(testAPI())
+
+	tests := []struct {
+		name       string
+		path       string
+		item       string
+		collection string
+		id         string
+		ok         bool
+	}{
+		{
+			name:       "collection",
+			path:       "/users",
+			item:       "/users/{userId}",
+			collection: "/users",
+			id:         "",
+			ok:         true,
+		},
+		{
+			name:       "item",
+			path:       "/users/1",
+			item:       "/users/{userId}",
+			collection: "/users",
+			id:         "1",
+			ok:         true,
+		},
+		{
+			name:       "nested item",
+			path:       "/users/1/posts/2",
+			item:       "/users/{userId}/posts/{postId}",
+			collection: "/users/1/posts",
+			id:         "2",
+			ok:         true,
+		},
+		{
+			name:       "nested collection",
+			path:       "/users/1/posts",
+			item:       "/users/{userId}/posts/{postId}",
+			collection: "/users/1/posts",
+			id:         "",
+			ok:         true,
+		},
+		{
+			name: "not resource",
+			path: "/healthz",
+			ok:   false,
+		},
+		{
+			name: "not resource under item",
+			path: "/users/1/comments",
+			ok:   false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, collection, id, ok := s.Match(tc.path)
+
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.item, r.Item)
+			require.Equal(t, tc.collection, collection)
+			require.Equal(t, tc.id, id)
+		})
+	}
+}
+
+func TestStore_CRUD(t *testing.T) {
+	s := store.NewStore(testAPI())
+	r, _, _, _ := s.Match("/users")
+
+	require.Len(t, s.List("/users"), 2)
+
+	created := s.Create("/users", r, map[string]interface{}{"name": "Larry"})
+	require.Equal(t, map[string]interface{}{"id": int64(3), "name": "Larry"}, created)
+
+	got, ok := s.Get("/users", "3")
+	require.True(t, ok)
+	require.Equal(t, created, got)
+
+	patched, ok := s.Patch("/users", r, "3", map[string]interface{}{"name": "Page", "id": 42})
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"id": int64(3), "name": "Page"}, patched)
+
+	put, created2 := s.Put("/users", r, "10", map[string]interface{}{"name": "Bill"})
+	require.True(t, created2)
+	require.Equal(t, map[string]interface{}{"id": int64(10), "name": "Bill"}, put)
+
+	require.True(t, s.Delete("/users", "1"))
+	require.False(t, s.Delete("/users", "1"))
+
+	_, ok = s.Get("/users", "1")
+	require.False(t, ok)
+
+	_, ok = s.Patch("/users", r, "1", map[string]interface{}{})
+	require.False(t, ok)
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"id": uint64(2), "name": "Sergey"},
+		map[string]interface{}{"id": int64(3), "name": "Page"},
+		map[string]interface{}{"id": int64(10), "name": "Bill"},
+	}, s.List("/users"))
+}
@@ -0,0 +1,261 @@
+package stub
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/neotoolkit/dummy/internal/api"
+)
+
+// ErrEmptyPath -.
+var ErrEmptyPath = errors.New("request path is required")
+
+// StatusError -.
+type StatusError struct {
+	Status int
+}
+
+// Error -.
+func (e *StatusError) Error() string {
+	return "response status must be between 100 and 599, got " + strconv.Itoa(e.Status)
+}
+
+// Stub is response which is served instead of response of specification if request matches
+type Stub struct {
+	ID       string   `json:"id"`
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request describes requests which are matched by stub, empty fields match any request
+type Request struct {
+	Method string `json:"method,omitempty"`
+	// Path is path template, e.g. /users/{userId}
+	Path   string            `json:"path"`
+	Query  map[string]string `json:"query,omitempty"`
+	Header map[string]string `json:"header,omitempty"`
+	// Body matches JSON body which contains every field of Body, other fields of request body are ignored
+	Body interface{} `json:"body,omitempty"`
+}
+
+// Response is response of stub
+type Response struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   interface{}       `json:"body,omitempty"`
+}
+
+// Match returns true if request with decoded JSON body matches stub
+func (s Stub) Match(r *http.Request, body interface{}) bool {
+	if s.Request.Method != "" && s.Request.Method != r.Method {
+		return false
+	}
+
+	if !api.IsPathMatchTemplate("/"+strings.Trim(r.URL.Path, "/"), s.Request.Path) {
+		return false
+	}
+
+	query := r.URL.Query()
+
+	for name, value := range s.Request.Query {
+		if !hasValue(query, name, value) {
+			return false
+		}
+	}
+
+	for name, value := range s.Request.Header {
+		if !hasValue(url.Values(r.Header), http.CanonicalHeaderKey(name), value) {
+			return false
+		}
+	}
+
+	return s.Request.Body == nil || contains(body, s.Request.Body)
+}
+
+func hasValue(values url.Values, name, value string) bool {
+	for _, v := range values[name] {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains returns true if value contains every field of sub, arrays must have the same length
+func contains(value, sub interface{}) bool {
+	switch s := sub.(type) {
+	case map[string]interface{}:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, field := range s {
+			if !contains(v[key], field) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		v, ok := value.([]interface{})
+		if !ok || len(v) != len(s) {
+			return false
+		}
+
+		for i := range s {
+			if !contains(v[i], s[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(value, sub)
+}
+
+// Stubs is concurrency-safe list of stubs
+type Stubs struct {
+	mu     sync.RWMutex
+	lastID int
+	stubs  []Stub
+}
+
+// NewStubs returns a new instance of Stubs
+func NewStubs() *Stubs {
+	return &Stubs{}
+}
+
+// Add validates stub and adds it, stub with the same identifier is replaced
+// Stub without identifier and status gets sequential identifier and status 200
+func (s *Stubs) Add(stub Stub) (Stub, error) {
+	if stub.Request.Path == "" {
+		return Stub{}, ErrEmptyPath
+	}
+
+	if stub.Response.Status == 0 {
+		stub.Response.Status = http.StatusOK
+	}
+
+	if stub.Response.Status < 100 || stub.Response.Status > 599 {
+		return Stub{}, &StatusError{Status: stub.Response.Status}
+	}
+
+	stub.Request.Method = strings.ToUpper(stub.Request.Method)
+	stub.Request.Path = "/" + strings.Trim(stub.Request.Path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stub.ID == "" {
+		stub.ID = s.nextID()
+	}
+
+	if i := s.index(stub.ID); i >= 0 {
+		s.stubs = append(s.stubs[:i], s.stubs[i+1:]...)
+	}
+
+	s.stubs = append(s.stubs, stub)
+
+	return stub, nil
+}
+
+// nextID returns the next sequential identifier which is not taken, client may set identifier of stub
+func (s *Stubs) nextID() string {
+	for {
+		s.lastID++
+
+		id := strconv.Itoa(s.lastID)
+		if s.index(id) < 0 {
+			return id
+		}
+	}
+}
+
+// index returns position of stub with identifier, -1 if there is no such stub
+func (s *Stubs) index(id string) int {
+	for i := range s.stubs {
+		if s.stubs[i].ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// List returns stubs in order of adding
+func (s *Stubs) List() []Stub {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]Stub, len(s.stubs))
+	copy(res, s.stubs)
+
+	return res
+}
+
+// Get returns stub by identifier
+func (s *Stubs) Get(id string) (Stub, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, stub := range s.stubs {
+		if stub.ID == id {
+			return stub, true
+		}
+	}
+
+	return Stub{}, false
+}
+
+// Delete removes stub by identifier, false is returned if there is no such stub
+func (s *Stubs) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(id)
+	if i < 0 {
+		return false
+	}
+
+	s.stubs = append(s.stubs[:i], s.stubs[i+1:]...)
+
+	return true
+}
+
+// Len returns number of stubs
+func (s *Stubs) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.stubs)
+}
+
+// Reset removes all stubs
+func (s *Stubs) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stubs = nil
+}
+
+// Find returns the last added stub which matches request with decoded JSON body
+func (s *Stubs) Find(r *http.Request, body interface{}) (Stub, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := len(s.stubs) - 1; i >= 0; i-- {
+		if s.stubs[i].Match(r, body) {
+			return s.stubs[i], true
+		}
+	}
+
+	return Stub{}, false
+}
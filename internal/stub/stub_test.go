@@ -0,0 +1,153 @@
+package stub_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/stub"
+)
+
+func TestStatusError(t *testing.T) {
+	got := &stub.StatusError{Status: 600}
+
+	require.Equal(t, "response status must be between 100 and 599, got 600", got.Error())
+}
+
+func TestStubs_Add(t *testing.T) {
+	s := stub.NewStubs()
+
+	got, err := s.Add(stub.Stub{Request: stub.Request{Method: "get", Path: "users/"}})
+
+	require.NoError(t, err)
+	require.Equal(t, stub.Stub{
+		ID:       "1",
+		Request:  stub.Request{Method: http.MethodGet, Path: "/users"},
+		Response: stub.Response{Status: http.StatusOK},
+	}, got)
+
+	_, err = s.Add(stub.Stub{ID: "1", Request: stub.Request{Path: "/users"}, Response: stub.Response{Status: http.StatusCreated}})
+
+	require.NoError(t, err)
+
+	got, ok := s.Get("1")
+
+	require.True(t, ok)
+	require.Equal(t, http.StatusCreated, got.Response.Status)
+	require.Equal(t, 1, s.Len())
+
+	_, err = s.Add(stub.Stub{})
+
+	require.ErrorIs(t, err, stub.ErrEmptyPath)
+
+	_, err = s.Add(stub.Stub{Request: stub.Request{Path: "/users"}, Response: stub.Response{Status: 1000}})
+
+	require.EqualError(t, err, "response status must be between 100 and 599, got 1000")
+
+	require.True(t, s.Delete("1"))
+	require.False(t, s.Delete("1"))
+	require.Empty(t, s.List())
+}
+
+func TestStubs_Add_ClientID(t *testing.T) {
+	s := stub.NewStubs()
+
+	_, err := s.Add(stub.Stub{ID: "1", Request: stub.Request{Path: "/users"}, Response: stub.Response{Status: http.StatusCreated}})
+	require.NoError(t, err)
+
+	_, err = s.Add(stub.Stub{ID: "2", Request: stub.Request{Path: "/orders"}})
+	require.NoError(t, err)
+
+	got, err := s.Add(stub.Stub{Request: stub.Request{Path: "/posts"}})
+
+	require.NoError(t, err)
+	require.Equal(t, "3", got.ID)
+	require.Equal(t, 3, s.Len())
+
+	first, ok := s.Get("1")
+
+	require.True(t, ok)
+	require.Equal(t, "/users", first.Request.Path)
+}
+
+func TestStubs_Find(t *testing.T) {
+	s := stub.NewStubs()
+
+	for _, st := range []stub.Stub{
+		{ID: "any", Request: stub.Request{Path: "/users/{userId}"}},
+		{ID: "header", Request: stub.Request{Method: http.MethodGet, Path: "/users/{userId}", Header: map[string]string{"x-tenant": "tesla"}}},
+		{ID: "query", Request: stub.Request{Path: "/users", Query: map[string]string{"limit": "1"}}},
+		{ID: "body", Request: stub.Request{Method: http.MethodPost, Path: "/users", Body: map[string]interface{}{
+			"firstName": "Elon",
+			"tags":      []interface{}{"ceo"},
+		}}},
+	} {
+		_, err := s.Add(st)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		header http.Header
+		body   interface{}
+		want   string
+	}{
+		{
+			name:   "path template",
+			method: http.MethodDelete,
+			target: "/users/1/",
+			want:   "any",
+		},
+		{
+			name:   "header",
+			method: http.MethodGet,
+			target: "/users/1",
+			header: http.Header{"X-Tenant": []string{"tesla"}},
+			want:   "header",
+		},
+		{
+			name:   "query",
+			method: http.MethodGet,
+			target: "/users?limit=1",
+			want:   "query",
+		},
+		{
+			name:   "body",
+			method: http.MethodPost,
+			target: "/users",
+			body:   map[string]interface{}{"firstName": "Elon", "lastName": "Musk", "tags": []interface{}{"ceo"}},
+			want:   "body",
+		},
+		{
+			name:   "other body",
+			method: http.MethodPost,
+			target: "/users",
+			body:   map[string]interface{}{"firstName": "Nikola", "tags": []interface{}{"ceo"}},
+			want:   "",
+		},
+		{
+			name:   "other path",
+			method: http.MethodGet,
+			target: "/users/1/friends",
+			want:   "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.target, nil)
+			for name, values := range tc.header {
+				r.Header[name] = values
+			}
+
+			got, ok := s.Find(r, tc.body)
+
+			require.Equal(t, tc.want != "", ok)
+			require.Equal(t, tc.want, got.ID)
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package swagger
+
+import (
+	"strings"
+
+	"github.com/neotoolkit/dummy/internal/openapi"
+)
+
+// Swagger is struct for Swagger 2.0 specification
+type Swagger struct {
+	Swagger     string                `json:"swagger" yaml:"swagger"`
+	Info        openapi.Info          `json:"info" yaml:"info"`
+	Host        string                `json:"host,omitempty" yaml:"host,omitempty"`
+	BasePath    string                `json:"basePath,omitempty" yaml:"basePath,omitempty"`
+	Schemes     []string              `json:"schemes,omitempty" yaml:"schemes,omitempty"`
+	Consumes    []string              `json:"consumes,omitempty" yaml:"consumes,omitempty"`
+	Produces    []string              `json:"produces,omitempty" yaml:"produces,omitempty"`
+	Paths       map[string]*Path      `json:"paths" yaml:"paths"`
+	Definitions openapi.Schemas       `json:"definitions,omitempty" yaml:"definitions,omitempty"`
+	Parameters  map[string]*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Responses   map[string]*Response  `json:"responses,omitempty" yaml:"responses,omitempty"`
+}
+
+// Path -.
+type Path struct {
+	Get        *Operation   `json:"get,omitempty" yaml:"get,omitempty"`
+	Post       *Operation   `json:"post,omitempty" yaml:"post,omitempty"`
+	Put        *Operation   `json:"put,omitempty" yaml:"put,omitempty"`
+	Patch      *Operation   `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete     *Operation   `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Head       *Operation   `json:"head,omitempty" yaml:"head,omitempty"`
+	Options    *Operation   `json:"options,omitempty" yaml:"options,omitempty"`
+	Parameters []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// Operation -.
+type Operation struct {
+	Consumes   []string             `json:"consumes,omitempty" yaml:"consumes,omitempty"`
+	Produces   []string             `json:"produces,omitempty" yaml:"produces,omitempty"`
+	Parameters []*Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Responses  map[string]*Response `json:"responses" yaml:"responses"`
+	Proxy      bool                 `json:"x-dummy-proxy,omitempty" yaml:"x-dummy-proxy,omitempty"`
+}
+
+// Parameter -.
+type Parameter struct {
+	Ref      string          `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name     string          `json:"name,omitempty" yaml:"name,omitempty"`
+	In       string          `json:"in,omitempty" yaml:"in,omitempty"`
+	Required bool            `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *openapi.Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Type     string          `json:"type,omitempty" yaml:"type,omitempty"`
+	Format   string          `json:"format,omitempty" yaml:"format,omitempty"`
+	Items    *openapi.Schema `json:"items,omitempty" yaml:"items,omitempty"`
+	Default  interface{}     `json:"default,omitempty" yaml:"default,omitempty"`
+
+	CollectionFormat string `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"`
+}
+
+// Response -.
+type Response struct {
+	Ref         string                 `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      *openapi.Schema        `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Headers     map[string]*Header     `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Examples    map[string]interface{} `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+// Header -.
+type Header struct {
+	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Type        string          `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string          `json:"format,omitempty" yaml:"format,omitempty"`
+	Items       *openapi.Schema `json:"items,omitempty" yaml:"items,omitempty"`
+	Default     interface{}     `json:"default,omitempty" yaml:"default,omitempty"`
+	Enum        []interface{}   `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Example     interface{}     `json:"x-example,omitempty" yaml:"x-example,omitempty"`
+}
+
+// ReferenceError -.
+type ReferenceError struct {
+	Ref string
+}
+
+// Error -.
+func (e *ReferenceError) Error() string {
+	return "unknown reference " + e.Ref
+}
+
+const (
+	definitionsPrefix = "#/definitions/"
+	parametersPrefix  = "#/parameters/"
+	responsesPrefix   = "#/responses/"
+	schemasPrefix     = "#/components/schemas/"
+
+	defaultMediaType = "application/json"
+)
+
+// OpenAPI converts Swagger 2.0 specification to OpenAPI 3 specification
+func (s Swagger) OpenAPI() (openapi.OpenAPI, error) {
+	oapi := openapi.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    s.Info,
+		Servers: s.servers(),
+		Paths:   make(openapi.Paths, len(s.Paths)),
+		Components: openapi.Components{
+			Schemas: make(openapi.Schemas, len(s.Definitions)),
+		},
+	}
+
+	for name, schema := range s.Definitions {
+		oapi.Components.Schemas[name] = convertSchema(schema)
+	}
+
+	for path, item := range s.Paths {
+		// path without operations is null in YAML, e.g. "/pets:" without value
+		if nil == item {
+			continue
+		}
+
+		p := &openapi.Path{}
+
+		operations := []struct {
+			from *Operation
+			to   **openapi.Operation
+		}{
+			{from: item.Get, to: &p.Get},
+			{from: item.Post, to: &p.Post},
+			{from: item.Put, to: &p.Put},
+			{from: item.Patch, to: &p.Patch},
+			{from: item.Delete, to: &p.Delete},
+			{from: item.Head, to: &p.Head},
+			{from: item.Options, to: &p.Options},
+		}
+
+		for _, o := range operations {
+			if nil == o.from {
+				continue
+			}
+
+			operation, err := s.operation(item.Parameters, o.from)
+			if err != nil {
+				return openapi.OpenAPI{}, err
+			}
+
+			*o.to = operation
+		}
+
+		oapi.Paths[path] = p
+	}
+
+	return oapi, nil
+}
+
+func (s Swagger) servers() openapi.Servers {
+	if s.Host == "" {
+		if s.BasePath == "" {
+			return nil
+		}
+
+		return openapi.Servers{{URL: s.BasePath}}
+	}
+
+	schemes := s.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	servers := make(openapi.Servers, len(schemes))
+
+	for i, scheme := range schemes {
+		servers[i] = &openapi.Server{URL: scheme + "://" + s.Host + s.BasePath}
+	}
+
+	return servers
+}
+
+func (s Swagger) operation(common []*Parameter, o *Operation) (*openapi.Operation, error) {
+	operation := &openapi.Operation{
+		Responses: make(openapi.Responses, len(o.Responses)),
+		Proxy:     o.Proxy,
+	}
+
+	consumes := mediaTypes(o.Consumes, s.Consumes)
+	produces := mediaTypes(o.Produces, s.Produces)
+
+	params, err := s.parameters(common, o.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	form := openapi.Schema{
+		Type:       "object",
+		Properties: openapi.Schemas{},
+	}
+
+	for _, param := range params {
+		switch param.In {
+		case "body":
+			operation.RequestBody = openapi.RequestBody{
+				Required: param.Required,
+				Content:  content(consumes, convertSchema(param.Schema), nil),
+			}
+		case "formData":
+			form.Properties[param.Name] = paramSchema(param)
+
+			if param.Required {
+				form.Required = append(form.Required, param.Name)
+			}
+		default:
+			p := openapi.Parameter{
+				Name:     param.Name,
+				In:       param.In,
+				Required: param.Required,
+				Schema:   paramSchema(param),
+			}
+
+			if param.Type == "array" {
+				p.Style, p.Explode = style(param)
+			}
+
+			operation.Parameters = append(operation.Parameters, p)
+		}
+	}
+
+	if len(form.Properties) > 0 {
+		operation.RequestBody = openapi.RequestBody{
+			Required: len(form.Required) > 0,
+			Content:  content(formMediaTypes(consumes), &form, nil),
+		}
+	}
+
+	for code, resp := range o.Responses {
+		r, err := s.response(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		description := r.Description
+
+		converted := &openapi.Response{
+			Description: &description,
+		}
+
+		if r.Schema != nil {
+			converted.Content = content(produces, convertSchema(r.Schema), r.Examples)
+		}
+
+		for name, h := range r.Headers {
+			if nil == h {
+				continue
+			}
+
+			if nil == converted.Headers {
+				converted.Headers = make(map[string]*openapi.Header, len(r.Headers))
+			}
+
+			converted.Headers[name] = &openapi.Header{
+				Description: h.Description,
+				Schema: &openapi.Schema{
+					Type:    h.Type,
+					Format:  h.Format,
+					Items:   convertSchema(h.Items),
+					Default: h.Default,
+					Enum:    h.Enum,
+				},
+				Example: h.Example,
+			}
+		}
+
+		operation.Responses[code] = converted
+	}
+
+	return operation, nil
+}
+
+func (s Swagger) parameters(common, own []*Parameter) ([]*Parameter, error) {
+	all := make([]*Parameter, 0, len(common)+len(own))
+	all = append(all, common...)
+	all = append(all, own...)
+
+	params := make([]*Parameter, 0, len(all))
+	index := make(map[string]int, len(all))
+
+	for _, param := range all {
+		if param.Ref != "" {
+			p, ok := s.Parameters[strings.TrimPrefix(param.Ref, parametersPrefix)]
+			if !ok {
+				return nil, &ReferenceError{Ref: param.Ref}
+			}
+
+			param = p
+		}
+
+		// operation parameters override path parameters with the same name and location
+		key := param.In + ":" + param.Name
+
+		if i, ok := index[key]; ok {
+			params[i] = param
+
+			continue
+		}
+
+		index[key] = len(params)
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+func (s Swagger) response(r *Response) (*Response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+
+	resp, ok := s.Responses[strings.TrimPrefix(r.Ref, responsesPrefix)]
+	if !ok {
+		return nil, &ReferenceError{Ref: r.Ref}
+	}
+
+	return resp, nil
+}
+
+func mediaTypes(own, global []string) []string {
+	if len(own) > 0 {
+		return own
+	}
+
+	if len(global) > 0 {
+		return global
+	}
+
+	return []string{defaultMediaType}
+}
+
+func formMediaTypes(consumes []string) []string {
+	var types []string
+
+	for _, mt := range consumes {
+		if mt == "application/x-www-form-urlencoded" || mt == "multipart/form-data" {
+			types = append(types, mt)
+		}
+	}
+
+	if len(types) == 0 {
+		return []string{"application/x-www-form-urlencoded"}
+	}
+
+	return types
+}
+
+func content(mediaTypes []string, schema *openapi.Schema, examples map[string]interface{}) openapi.Content {
+	c := make(openapi.Content, len(mediaTypes))
+
+	for _, mt := range mediaTypes {
+		c[mt] = &openapi.MediaType{
+			Example: examples[mt],
+		}
+
+		if schema != nil {
+			c[mt].Schema = *schema
+		}
+	}
+
+	return c
+}
+
+// style returns OpenAPI 3 style and explode of array parameter by collection format
+func style(p *Parameter) (string, *bool) {
+	explode := p.CollectionFormat == "multi"
+
+	switch p.CollectionFormat {
+	case "ssv":
+		return "spaceDelimited", &explode
+	case "pipes":
+		return "pipeDelimited", &explode
+	}
+
+	if p.In == "query" {
+		return "form", &explode
+	}
+
+	return "simple", &explode
+}
+
+func paramSchema(p *Parameter) *openapi.Schema {
+	if p.Schema != nil {
+		return convertSchema(p.Schema)
+	}
+
+	schema := &openapi.Schema{
+		Type:    p.Type,
+		Format:  p.Format,
+		Default: p.Default,
+		Items:   convertSchema(p.Items),
+	}
+
+	// file is not valid OpenAPI 3 type, it is described as binary string
+	if p.Type == "file" {
+		schema.Type = "string"
+		schema.Format = "binary"
+	}
+
+	return schema
+}
+
+func convertSchema(s *openapi.Schema) *openapi.Schema {
+	if nil == s {
+		return nil
+	}
+
+	schema := *s
+	schema.Ref = convertRef(s.Ref)
+	schema.Items = convertSchema(s.Items)
+
+	if s.Properties != nil {
+		schema.Properties = make(openapi.Schemas, len(s.Properties))
+
+		for name, prop := range s.Properties {
+			schema.Properties[name] = convertSchema(prop)
+		}
+	}
+
+	if s.AdditionalProperties != nil {
+		schema.AdditionalProperties = &openapi.AdditionalProperties{
+			Allowed: s.AdditionalProperties.Allowed,
+			Schema:  convertSchema(s.AdditionalProperties.Schema),
+		}
+	}
+
+	schema.AllOf = convertSchemas(s.AllOf)
+	schema.OneOf = convertSchemas(s.OneOf)
+	schema.AnyOf = convertSchemas(s.AnyOf)
+
+	if s.Discriminator != nil {
+		schema.Discriminator = &openapi.Discriminator{PropertyName: s.Discriminator.PropertyName}
+
+		if s.Discriminator.Mapping != nil {
+			schema.Discriminator.Mapping = make(map[string]string, len(s.Discriminator.Mapping))
+
+			for value, ref := range s.Discriminator.Mapping {
+				schema.Discriminator.Mapping[value] = convertRef(ref)
+			}
+		}
+	}
+
+	return &schema
+}
+
+func convertSchemas(schemas []*openapi.Schema) []*openapi.Schema {
+	if nil == schemas {
+		return nil
+	}
+
+	res := make([]*openapi.Schema, len(schemas))
+	for i, s := range schemas {
+		res[i] = convertSchema(s)
+	}
+
+	return res
+}
+
+// convertRef returns reference to components/schemas for reference to definitions
+func convertRef(ref string) string {
+	if strings.HasPrefix(ref, definitionsPrefix) {
+		return schemasPrefix + strings.TrimPrefix(ref, definitionsPrefix)
+	}
+
+	return ref
+}
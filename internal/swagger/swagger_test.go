@@ -0,0 +1,247 @@
+package swagger_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/neotoolkit/faker"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/api"
+	"github.com/neotoolkit/dummy/internal/openapi"
+	"github.com/neotoolkit/dummy/internal/swagger"
+)
+
+func TestReferenceError(t *testing.T) {
+	got := &swagger.ReferenceError{
+		Ref: "test",
+	}
+
+	require.Equal(t, got.Error(), "unknown reference test")
+}
+
+const spec = `
+swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+host: api.example.com
+basePath: /v1
+schemes:
+  - http
+produces:
+  - application/xml
+parameters:
+  Limit:
+    in: query
+    name: limit
+    type: integer
+paths:
+  /pets:
+    get:
+      produces:
+        - application/json
+      parameters:
+        - $ref: "#/parameters/Limit"
+        - in: query
+          name: tags
+          type: array
+          items:
+            type: string
+          collectionFormat: pipes
+      responses:
+        '200':
+          description: pets
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/Pet"
+          examples:
+            application/json:
+              - name: Rex
+    post:
+      consumes:
+        - multipart/form-data
+      parameters:
+        - in: formData
+          name: name
+          type: string
+          required: true
+        - in: formData
+          name: photo
+          type: file
+      responses:
+        '201':
+          description: created
+          headers:
+            Location:
+              type: string
+              format: uri
+              x-example: http://api.example.com/v1/pets/1
+  /pets/{petId}:
+    head:
+      responses:
+        '200':
+          description: exists
+    put:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            $ref: "#/definitions/Pet"
+      responses:
+        '204':
+          description: updated
+  /dogs/{dogId}:
+    get:
+      responses:
+        '200':
+          description: dog
+          schema:
+            $ref: "#/definitions/Dog"
+definitions:
+  Pet:
+    type: object
+    discriminator: petType
+    properties:
+      name:
+        type: string
+      petType:
+        type: string
+  Dog:
+    allOf:
+      - $ref: "#/definitions/Pet"
+      - type: object
+        properties:
+          toys:
+            type: object
+            additionalProperties:
+              $ref: "#/definitions/Toy"
+  Toy:
+    type: object
+    properties:
+      name:
+        type: string
+`
+
+func parse(t *testing.T, spec string) (openapi.OpenAPI, error) {
+	t.Helper()
+
+	var s swagger.Swagger
+
+	err := yaml.Unmarshal([]byte(spec), &s)
+	require.NoError(t, err)
+
+	return s.OpenAPI()
+}
+
+func TestSwagger_OpenAPI(t *testing.T) {
+	got, err := parse(t, spec)
+	require.NoError(t, err)
+
+	require.Equal(t, "http://api.example.com/v1", got.Servers[0].URL)
+	require.Contains(t, got.Components.Schemas, "Pet")
+
+	explode := false
+	get := got.Paths["/pets"].Get
+	require.Equal(t, openapi.Parameters{
+		{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+		{
+			Name:    "tags",
+			In:      "query",
+			Style:   "pipeDelimited",
+			Explode: &explode,
+			Schema:  &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "string"}},
+		},
+	}, get.Parameters)
+
+	content := get.Responses["200"].Content
+	require.Contains(t, content, "application/json")
+	require.Equal(t, "#/components/schemas/Pet", content["application/json"].Schema.Items.Ref)
+	require.Equal(t, []interface{}{map[string]interface{}{"name": "Rex"}}, content["application/json"].Example)
+
+	post := got.Paths["/pets"].Post
+	form := post.RequestBody.Content["multipart/form-data"]
+	require.True(t, post.RequestBody.Required)
+	require.Equal(t, []string{"name"}, form.Schema.Required)
+	require.Equal(t, "binary", form.Schema.Properties["photo"].Format)
+	require.Nil(t, post.Responses["201"].Content)
+	require.Equal(t, map[string]*openapi.Header{
+		"Location": {Schema: &openapi.Schema{Type: "string", Format: "uri"}, Example: "http://api.example.com/v1/pets/1"},
+	}, post.Responses["201"].Headers)
+
+	require.NotNil(t, got.Paths["/pets/{petId}"].Head)
+
+	put := got.Paths["/pets/{petId}"].Put
+	require.Equal(t, "#/components/schemas/Pet", put.RequestBody.Content["application/json"].Schema.Ref)
+
+	dog := got.Components.Schemas["Dog"]
+	require.Equal(t, "#/components/schemas/Pet", dog.AllOf[0].Ref)
+	require.Equal(t, "#/components/schemas/Toy", dog.AllOf[1].Properties["toys"].AdditionalProperties.Schema.Ref)
+	require.Equal(t, &openapi.Discriminator{PropertyName: "petType"}, got.Components.Schemas["Pet"].Discriminator)
+	require.Empty(t, api.Lint(got))
+
+	b := api.Builder{OpenAPI: got, Faker: faker.NewFaker()}
+	_, err = b.Build()
+	require.NoError(t, err)
+}
+
+func TestSwagger_OpenAPI_EmptyPath(t *testing.T) {
+	got, err := parse(t, `
+swagger: "2.0"
+paths:
+  /pets:
+  /pets/{petId}:
+    get:
+      responses:
+        '200':
+          description: pet
+`)
+	require.NoError(t, err)
+
+	require.NotContains(t, got.Paths, "/pets")
+	require.NotNil(t, got.Paths["/pets/{petId}"].Get)
+}
+
+func TestSwagger_OpenAPI_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		err  error
+	}{
+		{
+			name: "unknown parameter",
+			spec: `
+swagger: "2.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: "#/parameters/Unknown"
+      responses: {}
+`,
+			err: &swagger.ReferenceError{Ref: "#/parameters/Unknown"},
+		},
+		{
+			name: "unknown response",
+			spec: `
+swagger: "2.0"
+paths:
+  /pets:
+    get:
+      responses:
+        '404':
+          $ref: "#/responses/NotFound"
+`,
+			err: &swagger.ReferenceError{Ref: "#/responses/NotFound"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse(t, tc.spec)
+
+			require.EqualError(t, err, tc.err.Error())
+		})
+	}
+}
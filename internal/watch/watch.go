@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/neotoolkit/dummy/internal/read"
+)
+
+const (
+	// DefaultInterval is polling interval of local specification files
+	DefaultInterval = time.Second
+	// DefaultRemoteInterval is polling interval of remote specification
+	DefaultRemoteInterval = 30 * time.Second
+)
+
+// refPattern matches value of $ref in YAML or JSON document
+var refPattern = regexp.MustCompile(`\$ref["']?\s*:\s*["']?([^"'\s,}]+)`)
+
+// Watcher polls specification and files referenced by it and reports changes
+type Watcher struct {
+	Path string
+	// Interval is time between polls, zero means default interval for local or remote specification
+	Interval time.Duration
+
+	state string
+}
+
+// NewWatcher returns a new instance of Watcher with state of files at the moment of call
+func NewWatcher(path string, interval time.Duration) *Watcher {
+	w := &Watcher{
+		Path:     path,
+		Interval: interval,
+	}
+
+	w.state = w.fingerprint()
+
+	return w
+}
+
+// Run calls onChange every time specification or referenced files are changed, it returns when ctx is done
+func (w *Watcher) Run(ctx context.Context, onChange func()) {
+	ticker := time.NewTicker(w.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.Changed() {
+				onChange()
+			}
+		}
+	}
+}
+
+// Changed returns true if files are changed since the previous call
+func (w *Watcher) Changed() bool {
+	state := w.fingerprint()
+	if state == w.state {
+		return false
+	}
+
+	w.state = state
+
+	return true
+}
+
+func (w *Watcher) interval() time.Duration {
+	switch {
+	case w.Interval > 0:
+		return w.Interval
+	case isRemote(w.Path):
+		return DefaultRemoteInterval
+	default:
+		return DefaultInterval
+	}
+}
+
+// fingerprint returns state of files, remote specification is compared by content, local files by size and modification time
+func (w *Watcher) fingerprint() string {
+	if isRemote(w.Path) {
+		data, err := read.Read(w.Path)
+		if err != nil {
+			return err.Error()
+		}
+
+		return fmt.Sprintf("%x", sha256.Sum256(data))
+	}
+
+	var sb strings.Builder
+
+	for _, path := range Files(w.Path) {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(&sb, "%s:%v;", path, err)
+
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return sb.String()
+}
+
+// Files returns local specification and files referenced by it with $ref, references are followed recursively
+func Files(path string) []string {
+	files := []string{path}
+	visited := map[string]bool{filepath.Clean(path): true}
+
+	for i := 0; i < len(files); i++ {
+		data, err := os.ReadFile(files[i])
+		if err != nil {
+			continue
+		}
+
+		dir := filepath.Dir(files[i])
+
+		for _, match := range refPattern.FindAllSubmatch(data, -1) {
+			ref := strings.SplitN(string(match[1]), "#", 2)[0]
+			if ref == "" || isRemote(ref) {
+				continue
+			}
+
+			if !filepath.IsAbs(ref) {
+				ref = filepath.Join(dir, ref)
+			}
+
+			ref = filepath.Clean(ref)
+
+			if !visited[ref] {
+				visited[ref] = true
+				files = append(files, ref)
+			}
+		}
+	}
+
+	return files
+}
+
+func isRemote(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
@@ -0,0 +1,103 @@
+package watch_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/neotoolkit/dummy/internal/watch"
+)
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	spec := filepath.Join(dir, "openapi.yml")
+	writeFile(t, spec, `paths:
+  /users:
+    $ref: "./paths/users.yml"
+components:
+  schemas:
+    User:
+      $ref: '#/components/schemas/Person'
+    Remote:
+      $ref: https://example.com/schemas.yml#/Remote
+`)
+	writeFile(t, filepath.Join(dir, "paths", "users.yml"), `get:
+  responses:
+    '200':
+      $ref: ../schemas/user.json#/User
+`)
+	writeFile(t, filepath.Join(dir, "schemas", "user.json"), `{"User": {"type": "object"}}`)
+
+	require.Equal(t, []string{
+		spec,
+		filepath.Join(dir, "paths", "users.yml"),
+		filepath.Join(dir, "schemas", "user.json"),
+	}, watch.Files(spec))
+}
+
+func TestWatcher_Changed(t *testing.T) {
+	dir := t.TempDir()
+
+	spec := filepath.Join(dir, "openapi.yml")
+	ref := filepath.Join(dir, "user.yml")
+
+	writeFile(t, spec, `$ref: user.yml`)
+	writeFile(t, ref, `type: object`)
+
+	w := watch.NewWatcher(spec, 0)
+
+	require.False(t, w.Changed())
+
+	writeFile(t, ref, `type: string, format: uuid`)
+
+	require.True(t, w.Changed())
+	require.False(t, w.Changed())
+
+	require.NoError(t, os.Remove(ref))
+
+	require.True(t, w.Changed())
+}
+
+func TestWatcher_Run(t *testing.T) {
+	var version int64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "version: %d", atomic.LoadInt64(&version))
+	}))
+	defer srv.Close()
+
+	w := watch.NewWatcher(srv.URL, 10*time.Millisecond)
+
+	changes := make(chan struct{}, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go w.Run(ctx, func() {
+		changes <- struct{}{}
+	})
+
+	atomic.AddInt64(&version, 1)
+
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("change of remote specification is not detected")
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+}
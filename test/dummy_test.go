@@ -1,8 +1,11 @@
 package test_test
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/lamoda/gonkey/runner"
@@ -10,11 +13,16 @@ import (
 	"github.com/neotoolkit/dummy/internal/config"
 	"github.com/neotoolkit/dummy/internal/logger"
 	"github.com/neotoolkit/dummy/internal/parse"
+	"github.com/neotoolkit/dummy/internal/proxy"
 	"github.com/neotoolkit/dummy/internal/server"
+	"github.com/neotoolkit/dummy/internal/store"
 )
 
-func TestDummy(t *testing.T) {
-	api, err := parse.Parse("./testdata/openapi.yml")
+// newTestServer returns server of specification which is configured by options, it is closed with test
+func newTestServer(t *testing.T, spec string, opts ...func(s *server.Server)) *httptest.Server {
+	t.Helper()
+
+	api, err := parse.Parse(spec)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -22,15 +30,130 @@ func TestDummy(t *testing.T) {
 	s := new(server.Server)
 	conf := config.NewConfig()
 	s.Config = conf.Server
-	s.Handlers.API = api
 	s.Logger = logger.NewLogger(conf.Logger.Level)
+	s.Handlers = server.NewHandlers(api, s.Logger)
+	s.Handlers.Seeder = server.NewSeeder(1)
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	newServer := httptest.NewServer(s.Routes())
+	t.Cleanup(newServer.Close)
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", s.Handler)
-	newServer := httptest.NewServer(mux)
+	return newServer
+}
 
+func TestDummy(t *testing.T) {
 	runner.RunWithTesting(t, &runner.RunWithTestingParams{
-		Server:   newServer,
+		Server:   newTestServer(t, "./testdata/openapi.yml"),
 		TestsDir: "./testdata/cases.yml",
 	})
 }
+
+func TestDummy_Stateful(t *testing.T) {
+	stateful := func(s *server.Server) {
+		s.Handlers.SetStore(store.NewStore(s.Handlers.API()))
+	}
+
+	runner.RunWithTesting(t, &runner.RunWithTestingParams{
+		Server:   newTestServer(t, "./testdata/openapi.yml", stateful),
+		TestsDir: "./testdata/stateful.yml",
+	})
+}
+
+func TestDummy_Journal(t *testing.T) {
+	runner.RunWithTesting(t, &runner.RunWithTestingParams{
+		Server:   newTestServer(t, "./testdata/openapi.yml"),
+		TestsDir: "./testdata/journal.yml",
+	})
+}
+
+func TestDummy_Stubs(t *testing.T) {
+	runner.RunWithTesting(t, &runner.RunWithTestingParams{
+		Server:   newTestServer(t, "./testdata/openapi.yml"),
+		TestsDir: "./testdata/stubs.yml",
+	})
+}
+
+func TestDummy_Upstream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res := map[string]string{
+			"upstream": r.Method + " " + r.URL.RequestURI(),
+		}
+
+		if len(body) > 0 {
+			res["body"] = string(body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer upstream.Close()
+
+	u, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxied := func(s *server.Server) {
+		s.Handlers.Upstream = proxy.New(u, s.Logger)
+	}
+
+	runner.RunWithTesting(t, &runner.RunWithTestingParams{
+		Server:   newTestServer(t, "./testdata/openapi.yml", proxied),
+		TestsDir: "./testdata/upstream.yml",
+	})
+}
+
+func TestDummy_ContentType(t *testing.T) {
+	srv := newTestServer(t, "./testdata/openapi.yml")
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		statusCode  int
+		contentType string
+	}{
+		{name: "body", method: http.MethodGet, path: "/users", statusCode: http.StatusOK, contentType: "application/json"},
+		{name: "no content", method: http.MethodDelete, path: "/users/e1afccea-5168-4735-84d4-cb96f6fb5d25", statusCode: http.StatusNoContent},
+		{name: "not found", method: http.MethodGet, path: "/", statusCode: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPatch, path: "/users", statusCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, srv.URL+tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.statusCode {
+				t.Fatalf("status code: want %d, got %d", tc.statusCode, resp.StatusCode)
+			}
+
+			if got := resp.Header.Get("Content-Type"); got != tc.contentType {
+				t.Fatalf("Content-Type: want %q, got %q", tc.contentType, got)
+			}
+		})
+	}
+}